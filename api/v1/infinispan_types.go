@@ -155,6 +155,16 @@ type InfinispanSitesLocalSpec struct {
 	MaxRelayNodes int32 `json:"maxRelayNodes,omitempty"`
 	// +optional
 	Encryption *EncryptionSiteSpec `json:"encryption,omitempty"`
+	// +optional
+	StateTransfer *CrossSiteStateTransferSpec `json:"stateTransfer,omitempty"`
+}
+
+// CrossSiteStateTransferSpec configures the automatic synchronisation of remote sites
+type CrossSiteStateTransferSpec struct {
+	// If true, a remote site that rejoins the cross-site view is brought online and the state of the local caches is pushed to it
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Automatic Cross-Site State Transfer",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled,omitempty"`
 }
 
 type InfinispanSiteLocationSpec struct {
@@ -344,6 +354,14 @@ type ConfigListenerSpec struct {
 	Enabled bool `json:"enabled"`
 }
 
+// CacheAvailabilitySpec defines how the operator manages the availability of caches
+type CacheAvailabilitySpec struct {
+	// If true, caches in DEGRADED_MODE are restored to AVAILABLE once all pods have rejoined the cluster
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Automatically Recover Degraded Caches",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	AutoRecover bool `json:"autoRecover,omitempty"`
+}
+
 // InfinispanSpec defines the desired state of Infinispan
 type InfinispanSpec struct {
 	// The number of nodes in the Infinispan cluster.
@@ -376,6 +394,8 @@ type InfinispanSpec struct {
 	Upgrades *InfinispanUpgradesSpec `json:"upgrades,omitempty"`
 	// +optional
 	ConfigListener *ConfigListenerSpec `json:"configListener,omitempty"`
+	// +optional
+	CacheAvailability *CacheAvailabilitySpec `json:"cacheAvailability,omitempty"`
 }
 
 // InfinispanUpgradesSpec defines the Infinispan upgrade strategy
@@ -395,13 +415,15 @@ const (
 type ConditionType string
 
 const (
-	ConditionPrelimChecksPassed  ConditionType = "PreliminaryChecksPassed"
-	ConditionGracefulShutdown    ConditionType = "GracefulShutdown"
-	ConditionStopping            ConditionType = "Stopping"
-	ConditionUpgrade             ConditionType = "Upgrade"
-	ConditionWellFormed          ConditionType = "WellFormed"
-	ConditionCrossSiteViewFormed ConditionType = "CrossSiteViewFormed"
-	ConditionGossipRouterReady   ConditionType = "GossipRouterReady"
+	ConditionPrelimChecksPassed   ConditionType = "PreliminaryChecksPassed"
+	ConditionGracefulShutdown     ConditionType = "GracefulShutdown"
+	ConditionStopping             ConditionType = "Stopping"
+	ConditionUpgrade              ConditionType = "Upgrade"
+	ConditionWellFormed           ConditionType = "WellFormed"
+	ConditionCrossSiteViewFormed  ConditionType = "CrossSiteViewFormed"
+	ConditionGossipRouterReady    ConditionType = "GossipRouterReady"
+	ConditionCachesAvailable      ConditionType = "CachesAvailable"
+	ConditionRebalancingSuspended ConditionType = "RebalancingSuspended"
 )
 
 // InfinispanCondition define a condition of the cluster
@@ -434,6 +456,9 @@ type InfinispanStatus struct {
 	Security *InfinispanSecurity `json:"security,omitempty"`
 	// +optional
 	ReplicasWantedAtRestart int32 `json:"replicasWantedAtRestart,omitempty"`
+	// The time at which rebalancing was suspended by the operator, unset once rebalancing has been resumed
+	// +optional
+	RebalancingSuspendedTime *metav1.Time `json:"rebalancingSuspendedTime,omitempty"`
 	// The Pod's currently in the cluster
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Pod Status",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses"
@@ -444,6 +469,75 @@ type InfinispanStatus struct {
 	ConsoleUrl *string `json:"consoleUrl,omitempty"`
 	// +optional
 	HotRodRollingUpgradeStatus *HotRodRollingUpgradeStatus `json:"hotRodRollingUpgradeStatus,omitempty"`
+	// The Infinispan server image and version detected by the operator
+	// +optional
+	Operand *OperandStatus `json:"operand,omitempty"`
+	// The status of each remote site, populated once the cross-site view has formed
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Cross-Site Status"
+	Sites []CrossSiteStatus `json:"sites,omitempty"`
+}
+
+// CrossSiteStatus the state of a remote site as a backup location for the caches of the local site
+type CrossSiteStatus struct {
+	// The name of the remote site
+	Name string `json:"name"`
+	// The state of the site, one of online, offline or mixed. A site is mixed if it's online for some caches and
+	// offline for others
+	Status string `json:"status"`
+	// The caches for which the site is online. Only populated if the site is mixed
+	// +optional
+	OnlineCaches []string `json:"onlineCaches,omitempty"`
+	// The caches for which the site is offline. Only populated if the site is mixed
+	// +optional
+	OfflineCaches []string `json:"offlineCaches,omitempty"`
+	// The caches for which the site is online on some nodes and offline on others. Only populated if the site is mixed
+	// +optional
+	MixedCaches []string `json:"mixedCaches,omitempty"`
+	// The state transfer to the site initiated when it rejoined the cross-site view
+	// +optional
+	StateTransfer *CrossSiteStateTransferStatus `json:"stateTransfer,omitempty"`
+}
+
+type CrossSiteStateTransferPhase string
+
+const (
+	// CrossSiteStateTransferPending the site has left the cross-site view and state will be pushed once it rejoins
+	CrossSiteStateTransferPending CrossSiteStateTransferPhase = "Pending"
+	// CrossSiteStateTransferRunning state is being pushed to the site
+	CrossSiteStateTransferRunning CrossSiteStateTransferPhase = "Running"
+	// CrossSiteStateTransferSucceeded the state of all caches has been pushed to the site
+	CrossSiteStateTransferSucceeded CrossSiteStateTransferPhase = "Succeeded"
+	// CrossSiteStateTransferFailed the state of one or more caches could not be pushed to the site
+	CrossSiteStateTransferFailed CrossSiteStateTransferPhase = "Failed"
+)
+
+// CrossSiteStateTransferStatus the progress of a state transfer to a remote site
+type CrossSiteStateTransferStatus struct {
+	Phase CrossSiteStateTransferPhase `json:"phase"`
+	// The number of caches whose state has been pushed to the site
+	// +optional
+	CompletedCaches int32 `json:"completedCaches,omitempty"`
+	// The number of caches whose state is being pushed to the site
+	// +optional
+	TotalCaches int32 `json:"totalCaches,omitempty"`
+	// The caches whose state could not be pushed to the site
+	// +optional
+	FailedCaches []string `json:"failedCaches,omitempty"`
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+type OperandStatus struct {
+	// The image used by the Infinispan server pods when the version was detected
+	Image string `json:"image"`
+	// The version reported by the Infinispan server
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Infinispan Version"
+	Version string `json:"version"`
 }
 
 type HotRodRollingUpgradeStatus struct {
@@ -11,6 +11,7 @@ import (
 
 	"github.com/go-logr/logr"
 	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/version"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -228,6 +229,15 @@ func (ispn *Infinispan) ImageName() string {
 	return consts.DefaultImageName
 }
 
+// OperandVersion returns the server version detected for the current image, falling back to the version defined by the image tag
+// if the server version has not been detected yet
+func (ispn *Infinispan) OperandVersion() (*version.Version, error) {
+	if operand := ispn.Status.Operand; operand != nil && operand.Image == ispn.ImageName() && operand.Version != "" {
+		return version.FromString(operand.Version)
+	}
+	return version.FromImage(ispn.ImageName())
+}
+
 func (ispn *Infinispan) ImageType() ImageType {
 	if strings.Contains(ispn.ImageName(), consts.NativeImageMarker) {
 		return ImageTypeNative
@@ -285,6 +295,11 @@ func (ispn *Infinispan) HasSites() bool {
 	return ispn.IsDataGrid() && ispn.Spec.Service.Sites != nil
 }
 
+// IsCrossSiteStateTransferEnabled returns true if state should be pushed to remote sites when they rejoin the cross-site view
+func (ispn *Infinispan) IsCrossSiteStateTransferEnabled() bool {
+	return ispn.HasSites() && ispn.Spec.Service.Sites.Local.StateTransfer != nil && ispn.Spec.Service.Sites.Local.StateTransfer.Enabled
+}
+
 func (ispn *Infinispan) GetCrossSiteExposeType() CrossSiteExposeType {
 	return ispn.Spec.Service.Sites.Local.Expose.Type
 }
@@ -796,6 +811,11 @@ func (ispn *Infinispan) IsConfigListenerEnabled() bool {
 	return ispn.Spec.ConfigListener != nil && ispn.Spec.ConfigListener.Enabled
 }
 
+// IsCacheAutoRecoveryEnabled returns true if caches in DEGRADED_MODE should be restored to AVAILABLE by the operator
+func (ispn *Infinispan) IsCacheAutoRecoveryEnabled() bool {
+	return ispn.Spec.CacheAvailability != nil && ispn.Spec.CacheAvailability.AutoRecover
+}
+
 func (ispn *Infinispan) GetConfigListenerName() string {
 	return fmt.Sprintf("%s-config-listener", ispn.Name)
 }
@@ -72,3 +72,32 @@ func TestApplyOperatorLabels(t *testing.T) {
 		assert.True(t, reflect.DeepEqual(ispn.Labels, labelPodMap) || len(labelPodMap) == 0 && ispn.Labels == nil)
 	}
 }
+
+func TestOperandVersion(t *testing.T) {
+	image := "quay.io/infinispan/server:14.0"
+	ispn := &Infinispan{
+		Spec: InfinispanSpec{
+			Image: &image,
+		},
+	}
+	v, err := ispn.OperandVersion()
+	assert.Nil(t, err)
+	assert.Equal(t, "14.0.0", v.String(), "Version from image tag")
+
+	ispn.Status.Operand = &OperandStatus{
+		Image:   image,
+		Version: "Infinispan 'Flying Saucer' 14.0.1.Final",
+	}
+	v, err = ispn.OperandVersion()
+	assert.Nil(t, err)
+	assert.Equal(t, "14.0.1", v.String(), "Version detected from server")
+
+	ispn.Status.Operand.Image = "quay.io/infinispan/server:13.0"
+	v, err = ispn.OperandVersion()
+	assert.Nil(t, err)
+	assert.Equal(t, "14.0.0", v.String(), "Detected version ignored after image change")
+
+	image = "quay.io/infinispan/server@sha256:0123456789abcdef"
+	_, err = ispn.OperandVersion()
+	assert.NotNil(t, err, "Image without tag")
+}
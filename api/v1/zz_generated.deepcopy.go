@@ -66,6 +66,21 @@ func (in *Autoscale) DeepCopy() *Autoscale {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CacheAvailabilitySpec) DeepCopyInto(out *CacheAvailabilitySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CacheAvailabilitySpec.
+func (in *CacheAvailabilitySpec) DeepCopy() *CacheAvailabilitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CacheAvailabilitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigListenerSpec) DeepCopyInto(out *ConfigListenerSpec) {
 	*out = *in
@@ -118,6 +133,84 @@ func (in *CrossSiteKeyStore) DeepCopy() *CrossSiteKeyStore {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrossSiteStateTransferSpec) DeepCopyInto(out *CrossSiteStateTransferSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrossSiteStateTransferSpec.
+func (in *CrossSiteStateTransferSpec) DeepCopy() *CrossSiteStateTransferSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CrossSiteStateTransferSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrossSiteStateTransferStatus) DeepCopyInto(out *CrossSiteStateTransferStatus) {
+	*out = *in
+	if in.FailedCaches != nil {
+		in, out := &in.FailedCaches, &out.FailedCaches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrossSiteStateTransferStatus.
+func (in *CrossSiteStateTransferStatus) DeepCopy() *CrossSiteStateTransferStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CrossSiteStateTransferStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrossSiteStatus) DeepCopyInto(out *CrossSiteStatus) {
+	*out = *in
+	if in.OnlineCaches != nil {
+		in, out := &in.OnlineCaches, &out.OnlineCaches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OfflineCaches != nil {
+		in, out := &in.OfflineCaches, &out.OfflineCaches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MixedCaches != nil {
+		in, out := &in.MixedCaches, &out.MixedCaches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StateTransfer != nil {
+		in, out := &in.StateTransfer, &out.StateTransfer
+		*out = new(CrossSiteStateTransferStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrossSiteStatus.
+func (in *CrossSiteStatus) DeepCopy() *CrossSiteStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CrossSiteStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CrossSiteTrustStore) DeepCopyInto(out *CrossSiteTrustStore) {
 	*out = *in
@@ -507,6 +600,11 @@ func (in *InfinispanSitesLocalSpec) DeepCopyInto(out *InfinispanSitesLocalSpec)
 		*out = new(EncryptionSiteSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.StateTransfer != nil {
+		in, out := &in.StateTransfer, &out.StateTransfer
+		*out = new(CrossSiteStateTransferSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanSitesLocalSpec.
@@ -593,6 +691,11 @@ func (in *InfinispanSpec) DeepCopyInto(out *InfinispanSpec) {
 		*out = new(ConfigListenerSpec)
 		**out = **in
 	}
+	if in.CacheAvailability != nil {
+		in, out := &in.CacheAvailability, &out.CacheAvailability
+		*out = new(CacheAvailabilitySpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanSpec.
@@ -618,6 +721,10 @@ func (in *InfinispanStatus) DeepCopyInto(out *InfinispanStatus) {
 		*out = new(InfinispanSecurity)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RebalancingSuspendedTime != nil {
+		in, out := &in.RebalancingSuspendedTime, &out.RebalancingSuspendedTime
+		*out = (*in).DeepCopy()
+	}
 	in.PodStatus.DeepCopyInto(&out.PodStatus)
 	if in.ConsoleUrl != nil {
 		in, out := &in.ConsoleUrl, &out.ConsoleUrl
@@ -629,6 +736,18 @@ func (in *InfinispanStatus) DeepCopyInto(out *InfinispanStatus) {
 		*out = new(HotRodRollingUpgradeStatus)
 		**out = **in
 	}
+	if in.Operand != nil {
+		in, out := &in.Operand, &out.Operand
+		*out = new(OperandStatus)
+		**out = **in
+	}
+	if in.Sites != nil {
+		in, out := &in.Sites, &out.Sites
+		*out = make([]CrossSiteStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanStatus.
@@ -655,3 +774,18 @@ func (in *InfinispanUpgradesSpec) DeepCopy() *InfinispanUpgradesSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperandStatus) DeepCopyInto(out *OperandStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandStatus.
+func (in *OperandStatus) DeepCopy() *OperandStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OperandStatus)
+	in.DeepCopyInto(out)
+	return out
+}
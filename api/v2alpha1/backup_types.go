@@ -16,11 +16,86 @@ type BackupSpec struct {
 	Resources *BackupResources `json:"resources,omitempty"`
 	// +optional
 	Container v1.InfinispanContainerSpec `json:"container,omitempty"`
+	// The location that the backup archive is uploaded to once it has been created. If undefined the archive is
+	// only stored on the Backup's PersistentVolumeClaim
+	// +optional
+	Target *BackupTargetSpec `json:"target,omitempty"`
+	// Encrypts the backup archive once it has been created
+	// +optional
+	Encryption *BackupEncryptionSpec `json:"encryption,omitempty"`
+	// If true the Infinispan CR, its Cache CRs, user identity Secret and custom ConfigMap are stored alongside the
+	// backup archive so that the cluster can be recreated by a Restore. Requires encryption to be defined, so that the
+	// user identity Secret is not stored in plaintext
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Include Kubernetes Resources",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	IncludeKubernetesResources bool `json:"includeKubernetesResources,omitempty"`
+}
+
+// BackupEncryptionSpec references the key used to encrypt and decrypt a backup archive
+type BackupEncryptionSpec struct {
+	// The name of the Secret containing the 256 bit encryption key
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Encryption Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	SecretName string `json:"secretName"`
+	// The key within the Secret that contains the encryption key. Defaults to "key"
+	// +optional
+	SecretKey string `json:"secretKey,omitempty"`
+}
+
+type BackupTargetSpec struct {
+	// Uploads the backup archive to S3 compatible object storage
+	// +optional
+	S3 *BackupS3Spec `json:"s3,omitempty"`
+	// Determines whether the uploaded objects are removed when the Backup is deleted. Defaults to Retain, so that
+	// deleting the Backup's namespace or BackupSchedule does not remove the uploaded archive. Objects are always
+	// removed when a Backup expires according to the retention policy of its BackupSchedule
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Deletion Policy"
+	DeletionPolicy BackupDeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// BackupDeletionPolicy determines what happens to the uploaded backup archive when the Backup is deleted
+// +kubebuilder:validation:Enum=Retain;Delete
+type BackupDeletionPolicy string
+
+const (
+	BackupDeletionPolicyRetain BackupDeletionPolicy = "Retain"
+	BackupDeletionPolicyDelete BackupDeletionPolicy = "Delete"
+)
+
+// BackupS3Spec defines the location of a backup archive in S3 compatible object storage
+type BackupS3Spec struct {
+	// The URL of the S3 compatible endpoint, e.g. https://s3.eu-west-1.amazonaws.com or http://minio:9000
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="S3 Endpoint"
+	Endpoint string `json:"endpoint"`
+	// The region used to sign requests. Defaults to us-east-1
+	// +optional
+	Region string `json:"region,omitempty"`
+	// The name of the bucket that stores the backup archive
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="S3 Bucket"
+	Bucket string `json:"bucket"`
+	// The prefix prepended to the key of the backup archive
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+	// The name of the Secret containing the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY used to authenticate with the endpoint
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="S3 Credentials Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	CredentialsSecret string `json:"credentialsSecret"`
+	// The name of the Secret containing the ca.crt used to verify the endpoint's certificate, required when the
+	// endpoint's certificate is not signed by a trusted CA, e.g. when it is self-signed
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="S3 CA Certificate Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	CACertSecret string `json:"caCertSecret,omitempty"`
 }
 
 type BackupVolumeSpec struct {
+	// The size of the PersistentVolumeClaim. If undefined, the size is estimated from the data stored in the cluster
 	// +optional
 	Storage *string `json:"storage,omitempty"`
+	// The additional capacity, as a percentage of the estimated backup size, added to the PersistentVolumeClaim when
+	// the storage size is estimated. Defaults to 50
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Storage Headroom Percent",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	HeadroomPercent *int32 `json:"headroomPercent,omitempty"`
 	// +optional
 	// Names the storage class object for persistent volume claims.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Storage Class Name",xDescriptors="urn:alm:descriptor:io.kubernetes:StorageClass"
@@ -77,6 +152,47 @@ type BackupStatus struct {
 	// The name of the created PersistentVolumeClaim used to store the backup
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Persistent Volume Claim"
 	PVC string `json:"pvc,omitempty"`
+	// The location of the backup archive in object storage
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Location"
+	Location string `json:"location,omitempty"`
+	// The encryption applied to the backup archive
+	// +optional
+	Encryption *BackupEncryptionStatus `json:"encryption,omitempty"`
+	// The time the backup was started on the server
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// The time the backup archive was completed
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Completion Time"
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// A summary of the manifest stored alongside the backup archive
+	// +optional
+	Manifest *BackupManifestStatus `json:"manifest,omitempty"`
+}
+
+// BackupManifestStatus summarizes the manifest describing a backup archive
+type BackupManifestStatus struct {
+	// The location of the manifest
+	Location string `json:"location"`
+	// The version of the server that created the archive
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Server Version"
+	ServerVersion string `json:"serverVersion"`
+	// The size of the archive in bytes
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Archive Size"
+	Size int64 `json:"size"`
+	// The SHA-256 digest of the archive
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Archive Digest"
+	Digest string `json:"digest"`
+}
+
+// BackupEncryptionStatus identifies how a backup archive was encrypted
+type BackupEncryptionStatus struct {
+	// The encryption algorithm
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Encryption Algorithm"
+	Algorithm string `json:"algorithm"`
+	// The fingerprint of the key used to encrypt the archive
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Encryption Key ID"
+	KeyID string `json:"keyId"`
 }
 
 // +kubebuilder:object:root=true
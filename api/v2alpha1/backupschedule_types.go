@@ -0,0 +1,107 @@
+package v2alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type BackupScheduleConditionType string
+
+const (
+	// BackupScheduleConditionReady indicates whether the schedule is valid and Backups are being created
+	BackupScheduleConditionReady BackupScheduleConditionType = "Ready"
+)
+
+// BackupScheduleSpec defines the desired state of BackupSchedule
+type BackupScheduleSpec struct {
+	// The cron schedule, in the standard five field format, on which Backups are created
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Schedule"
+	Schedule string `json:"schedule"`
+	// Suspends the creation of Backups, existing Backups are still subject to the retention policy
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Suspend",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Suspend bool `json:"suspend,omitempty"`
+	// The spec of the Backups created by the schedule
+	Template BackupSpec `json:"template"`
+	// Determines which of the created Backups are retained. All Backups are retained if undefined
+	// +optional
+	Retention *BackupRetentionSpec `json:"retention,omitempty"`
+}
+
+// BackupRetentionSpec defines which successful Backups are retained. A Backup is retained if it satisfies any of the
+// rules. Failed Backups are removed once a more recent Backup has succeeded
+type BackupRetentionSpec struct {
+	// The number of most recent Backups to retain
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Keep Last"
+	Last int32 `json:"last,omitempty"`
+	// The number of days for which the most recent Backup of the day is retained
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Keep Daily"
+	Daily int32 `json:"daily,omitempty"`
+	// The number of weeks for which the most recent Backup of the week is retained
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Keep Weekly"
+	Weekly int32 `json:"weekly,omitempty"`
+}
+
+// BackupScheduleCondition define a condition of the backup schedule
+type BackupScheduleCondition struct {
+	// Type is the type of the condition.
+	Type BackupScheduleConditionType `json:"type"`
+	// Status is the status of the condition.
+	Status metav1.ConditionStatus `json:"status"`
+	// Human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// BackupScheduleStatus defines the observed state of BackupSchedule
+type BackupScheduleStatus struct {
+	// Conditions list for this backup schedule
+	// +optional
+	Conditions []BackupScheduleCondition `json:"conditions,omitempty"`
+	// The name of the most recently created Backup
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Last Backup"
+	LastBackup string `json:"lastBackup,omitempty"`
+	// The time a Backup was last scheduled, including runs that were skipped
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// The time the most recent successful Backup was created
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Last Successful Time"
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+	// The time of the next scheduled Backup
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Next Schedule Time"
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=backupschedules,scope=Namespaced
+// +kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule"
+// +kubebuilder:printcolumn:name="Last Backup",type="string",JSONPath=".status.lastBackup"
+// +kubebuilder:printcolumn:name="Next Schedule",type="date",JSONPath=".status.nextScheduleTime"
+// BackupSchedule is the Schema for the backupschedules API
+type BackupSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackupScheduleSpec   `json:"spec,omitempty"`
+	Status BackupScheduleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BackupScheduleList contains a list of BackupSchedule
+type BackupScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BackupSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupSchedule{}, &BackupScheduleList{})
+}
@@ -65,6 +65,35 @@ type CacheStatus struct {
 	// Deprecated. This is no longer set. Service name that exposes the cache inside the cluster
 	// +optional
 	ServiceName string `json:"serviceName,omitempty"`
+	// A periodically refreshed summary of the cache statistics
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Statistics"
+	Stats *CacheStatsSummary `json:"stats,omitempty"`
+}
+
+// CacheStatsSummary a summary of the cache statistics reported by the server. Statistics must be enabled in the cache
+// configuration for the counters to be populated
+type CacheStatsSummary struct {
+	// The number of entries in the cache
+	Entries int64 `json:"entries"`
+	// The number of read operations that found an entry
+	Hits int64 `json:"hits"`
+	// The number of read operations that did not find an entry
+	Misses int64 `json:"misses"`
+	// The number of entries evicted from the cache
+	Evictions int64 `json:"evictions"`
+	// The memory used by the cache data, in bytes
+	MemoryUsed int64 `json:"memoryUsed"`
+	// The average time taken to read an entry, in nanoseconds
+	AverageReadTime int64 `json:"averageReadTime"`
+	// The average time taken to write an entry, in nanoseconds
+	AverageWriteTime int64 `json:"averageWriteTime"`
+	// Whether the cache's data is currently being rebalanced across the cluster
+	Rebalancing bool `json:"rebalancing"`
+	// Whether rebalancing is enabled for the cache
+	RebalancingEnabled bool `json:"rebalancingEnabled"`
+	// The time the statistics were last retrieved
+	LastUpdated metav1.Time `json:"lastUpdated"`
 }
 
 // +kubebuilder:object:root=true
@@ -72,6 +101,14 @@ type CacheStatus struct {
 // Cache is the Schema for the caches API
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:path=caches,scope=Namespaced
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterName"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="Entries",type="integer",JSONPath=".status.stats.entries"
+// +kubebuilder:printcolumn:name="Memory",type="integer",JSONPath=".status.stats.memoryUsed",priority=1
+// +kubebuilder:printcolumn:name="Hits",type="integer",JSONPath=".status.stats.hits",priority=1
+// +kubebuilder:printcolumn:name="Misses",type="integer",JSONPath=".status.stats.misses",priority=1
+// +kubebuilder:printcolumn:name="Rebalancing",type="boolean",JSONPath=".status.stats.rebalancing"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type Cache struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -0,0 +1,101 @@
+package v2alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CounterConditionType string
+
+const (
+	CounterConditionReady CounterConditionType = "Ready"
+)
+
+// CounterType the type of the clustered counter
+// +kubebuilder:validation:Enum=Strong;Weak
+type CounterType string
+
+const (
+	CounterTypeStrong CounterType = "Strong"
+	CounterTypeWeak   CounterType = "Weak"
+)
+
+// CounterStorage determines whether the counter value survives a cluster restart
+// +kubebuilder:validation:Enum=Volatile;Persistent
+type CounterStorage string
+
+const (
+	CounterStorageVolatile   CounterStorage = "Volatile"
+	CounterStoragePersistent CounterStorage = "Persistent"
+)
+
+// CounterSpec defines the desired state of Counter
+type CounterSpec struct {
+	// Infinispan cluster name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cluster Name",xDescriptors="urn:alm:descriptor:io.kubernetes:infinispan.org:v1:Infinispan"
+	ClusterName string `json:"clusterName"`
+	// Name of the counter to be created. If empty ObjectMeta.Name will be used
+	// +optional
+	Name string `json:"name,omitempty"`
+	// The type of the counter. Defaults to Strong
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Counter Type",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Strong","urn:alm:descriptor:com.tectonic.ui:select:Weak"}
+	Type CounterType `json:"type,omitempty"`
+	// The initial value of the counter
+	// +optional
+	InitialValue int64 `json:"initialValue,omitempty"`
+	// The storage mode of the counter. Defaults to Volatile
+	// +optional
+	Storage CounterStorage `json:"storage,omitempty"`
+	// The upper bound of a Strong counter
+	// +optional
+	UpperBound *int64 `json:"upperBound,omitempty"`
+	// The lower bound of a Strong counter
+	// +optional
+	LowerBound *int64 `json:"lowerBound,omitempty"`
+	// The concurrency level of a Weak counter
+	// +optional
+	ConcurrencyLevel *int32 `json:"concurrencyLevel,omitempty"`
+}
+
+// CounterCondition define a condition of the counter
+type CounterCondition struct {
+	// Type is the type of the condition.
+	Type CounterConditionType `json:"type"`
+	// Status is the status of the condition.
+	Status metav1.ConditionStatus `json:"status"`
+	// Human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// CounterStatus defines the observed state of Counter
+type CounterStatus struct {
+	// Conditions list for this counter
+	// +optional
+	Conditions []CounterCondition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// Counter is the Schema for the counters API
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=counters,scope=Namespaced
+type Counter struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CounterSpec   `json:"spec,omitempty"`
+	Status CounterStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// CounterList contains a list of Counter
+type CounterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Counter `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Counter{}, &CounterList{})
+}
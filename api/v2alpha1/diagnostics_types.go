@@ -0,0 +1,103 @@
+package v2alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DiagnosticsSpec defines the desired state of Diagnostics
+type DiagnosticsSpec struct {
+	// Infinispan cluster name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cluster Name",xDescriptors="urn:alm:descriptor:io.kubernetes:infinispan.org:v1:Infinispan"
+	Cluster string `json:"cluster"`
+	// Stores the diagnostics archive in a PersistentVolumeClaim. If undefined, the archive is stored in a ConfigMap with
+	// the same name as the Diagnostics CR, which limits the size of the archive to 1MiB
+	// +optional
+	Volume *DiagnosticsVolumeSpec `json:"volume,omitempty"`
+}
+
+type DiagnosticsVolumeSpec struct {
+	// The name of an existing PersistentVolumeClaim. If empty, a PersistentVolumeClaim with the same name as the
+	// Diagnostics CR is created
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Persistent Volume Claim",xDescriptors="urn:alm:descriptor:io.kubernetes:PersistentVolumeClaim"
+	ClaimName string `json:"claimName,omitempty"`
+	// +optional
+	Storage *string `json:"storage,omitempty"`
+	// +optional
+	// Names the storage class object for persistent volume claims.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Storage Class Name",xDescriptors="urn:alm:descriptor:io.kubernetes:StorageClass"
+	StorageClassName *string `json:"storageClassName,omitempty"`
+}
+
+type DiagnosticsPhase string
+
+const (
+	// DiagnosticsInitializing means the request has been accepted, but the storage for the archive is still being initialized
+	DiagnosticsInitializing DiagnosticsPhase = "Initializing"
+	// DiagnosticsSucceeded means that the diagnostics have been collected and the archive stored
+	DiagnosticsSucceeded DiagnosticsPhase = "Succeeded"
+	// DiagnosticsFailed means that the archive could not be created or stored
+	DiagnosticsFailed DiagnosticsPhase = "Failed"
+)
+
+// DiagnosticsArtifact describes a single file contained in the diagnostics archive
+type DiagnosticsArtifact struct {
+	// The path of the file within the archive
+	Path string `json:"path"`
+	// The pod the artifact was collected from, empty for cluster wide artifacts
+	// +optional
+	Pod string `json:"pod,omitempty"`
+	// The size of the file in bytes
+	Size int64 `json:"size"`
+	// The reason the artifact could not be collected
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// DiagnosticsStatus defines the observed state of Diagnostics
+type DiagnosticsStatus struct {
+	// Current phase of the diagnostics collection
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Phase"
+	Phase DiagnosticsPhase `json:"phase,omitempty"`
+	// Reason indicates the reason for any diagnostics related failures.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Reason"
+	Reason string `json:"reason,omitempty"`
+	// The location of the diagnostics archive, either configmap/<name> or pvc/<name>/<file>
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Archive"
+	Archive string `json:"archive,omitempty"`
+	// The artifacts contained in the archive
+	// +optional
+	Artifacts []DiagnosticsArtifact `json:"artifacts,omitempty"`
+	// The time the diagnostics were collected
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=diagnostics,scope=Namespaced
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.cluster"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Archive",type="string",JSONPath=".status.archive"
+// Diagnostics is the Schema for the diagnostics API
+type Diagnostics struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DiagnosticsSpec   `json:"spec,omitempty"`
+	Status DiagnosticsStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DiagnosticsList contains a list of Diagnostics
+type DiagnosticsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Diagnostics `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Diagnostics{}, &DiagnosticsList{})
+}
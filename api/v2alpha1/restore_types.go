@@ -10,13 +10,56 @@ type RestoreSpec struct {
 	// Infinispan cluster name
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cluster Name",xDescriptors="urn:alm:descriptor:io.kubernetes:infinispan.org:v1:Infinispan"
 	Cluster string `json:"cluster"`
-	// The Infinispan Backup to restore
+	// The Infinispan Backup to restore. When a source is defined, the name of the Backup the archive was created by.
+	// Required unless the archive path of a volume source is defined
+	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Backup Name",xDescriptors="urn:alm:descriptor:io.kubernetes:infinispan.org:v2alpha1:Backup"
-	Backup string `json:"backup"`
+	Backup string `json:"backup,omitempty"`
 	// +optional
 	Resources *RestoreResources `json:"resources,omitempty"`
 	// +optional
 	Container v1.InfinispanContainerSpec `json:"container,omitempty"`
+	// The location that the backup archive is retrieved from. If undefined the archive is read from the
+	// PersistentVolumeClaim of the Backup
+	// +optional
+	Source *RestoreSourceSpec `json:"source,omitempty"`
+	// The key used to decrypt the backup archive. Required if the archive was encrypted
+	// +optional
+	Encryption *BackupEncryptionSpec `json:"encryption,omitempty"`
+	// If true the Kubernetes resources stored by the Backup are recreated in the Restore's namespace before the data
+	// is restored. The Infinispan CR is renamed to the Restore's cluster and existing resources are not modified
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Include Kubernetes Resources",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	IncludeKubernetesResources bool `json:"includeKubernetesResources,omitempty"`
+}
+
+// RestoreSourceSpec defines the location of the backup archive. Only one of the sources may be defined
+type RestoreSourceSpec struct {
+	// Downloads the backup archive from S3 compatible object storage
+	// +optional
+	S3 *BackupS3Spec `json:"s3,omitempty"`
+	// Reads the backup archive from a volume
+	// +optional
+	Volume *RestoreVolumeSourceSpec `json:"volume,omitempty"`
+}
+
+// RestoreVolumeSourceSpec defines a volume containing a backup archive, so that archives created in other namespaces or
+// clusters can be restored. Exactly one of claimName or volumeName must be defined
+type RestoreVolumeSourceSpec struct {
+	// The name of a PersistentVolumeClaim in the Restore's namespace that contains the backup archive
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Claim Name",xDescriptors="urn:alm:descriptor:io.kubernetes:PersistentVolumeClaim"
+	ClaimName string `json:"claimName,omitempty"`
+	// The name of a PersistentVolume that contains the backup archive, e.g. the volume of a Backup created in another
+	// namespace. A read-only PersistentVolumeClaim bound to the volume is created in the Restore's namespace, so the
+	// volume must not be bound to another claim
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Volume Name"
+	VolumeName string `json:"volumeName,omitempty"`
+	// The path of the backup archive relative to the root of the volume. Defaults to <backup>/<backup>.zip
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Archive Path"
+	Path string `json:"path,omitempty"`
 }
 
 type RestoreResources struct {
@@ -0,0 +1,100 @@
+package v2alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type TaskConditionType string
+
+const (
+	// TaskConditionReady indicates that the task script has been uploaded to the server
+	TaskConditionReady TaskConditionType = "Ready"
+	// TaskConditionSucceeded indicates whether the last execution of the task succeeded
+	TaskConditionSucceeded TaskConditionType = "Succeeded"
+)
+
+// TaskSpec defines the desired state of Task
+type TaskSpec struct {
+	// Infinispan cluster name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cluster Name",xDescriptors="urn:alm:descriptor:io.kubernetes:infinispan.org:v1:Infinispan"
+	ClusterName string `json:"clusterName"`
+	// Name of the task to be created. If empty ObjectMeta.Name will be used
+	// +optional
+	Name string `json:"name,omitempty"`
+	// The ConfigMap key containing the task script
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="ConfigMap Key",xDescriptors="urn:alm:descriptor:io.kubernetes:ConfigMap"
+	ConfigMapKeyRef corev1.ConfigMapKeySelector `json:"configMapKeyRef"`
+	// Executes the task once it has been uploaded. If undefined, the task is only uploaded
+	// +optional
+	Run *TaskRunSpec `json:"run,omitempty"`
+}
+
+// TaskRunSpec defines how the task is executed
+type TaskRunSpec struct {
+	// The cron schedule, in the standard five field format, on which the task is executed. If empty, the task is executed once
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Schedule"
+	Schedule string `json:"schedule,omitempty"`
+	// The parameters passed to the task on execution
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// TaskCondition define a condition of the task
+type TaskCondition struct {
+	// Type is the type of the condition.
+	Type TaskConditionType `json:"type"`
+	// Status is the status of the condition.
+	Status metav1.ConditionStatus `json:"status"`
+	// Human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// TaskStatus defines the observed state of Task
+type TaskStatus struct {
+	// Conditions list for this task
+	// +optional
+	Conditions []TaskCondition `json:"conditions,omitempty"`
+	// The SHA-256 digest of the script uploaded to the server
+	// +optional
+	ScriptDigest string `json:"scriptDigest,omitempty"`
+	// The time the task was last executed
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Last Execution Time"
+	LastExecutionTime *metav1.Time `json:"lastExecutionTime,omitempty"`
+	// The time of the next scheduled execution
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Next Execution Time"
+	NextExecutionTime *metav1.Time `json:"nextExecutionTime,omitempty"`
+	// The result returned by the last execution, truncated if too large
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Result"
+	Result string `json:"result,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// Task is the Schema for the tasks API
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=tasks,scope=Namespaced
+type Task struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TaskSpec   `json:"spec,omitempty"`
+	Status TaskStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// TaskList contains a list of Task
+type TaskList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Task `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Task{}, &TaskList{})
+}
@@ -1,6 +1,9 @@
 package v2alpha1
 
 import (
+	"path"
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -32,3 +35,181 @@ func (cache *Cache) GetCacheName() string {
 	}
 	return cache.Name
 }
+
+// SetCondition set condition to status
+func (counter *Counter) SetCondition(condition CounterConditionType, status metav1.ConditionStatus, message string) bool {
+	changed := false
+	for idx := range counter.Status.Conditions {
+		c := &counter.Status.Conditions[idx]
+		if c.Type == condition {
+			if c.Status != status {
+				c.Status = status
+				changed = true
+			}
+			if c.Message != message {
+				c.Message = message
+				changed = true
+			}
+
+			return changed
+		}
+	}
+	counter.Status.Conditions = append(counter.Status.Conditions, CounterCondition{Type: condition, Status: status, Message: message})
+	return true
+}
+
+func (counter *Counter) GetCounterName() string {
+	if counter.Spec.Name != "" {
+		return counter.Spec.Name
+	}
+	return counter.Name
+}
+
+// SetCondition set condition to status
+func (schema *Schema) SetCondition(condition SchemaConditionType, status metav1.ConditionStatus, message string) bool {
+	changed := false
+	for idx := range schema.Status.Conditions {
+		c := &schema.Status.Conditions[idx]
+		if c.Type == condition {
+			if c.Status != status {
+				c.Status = status
+				changed = true
+			}
+			if c.Message != message {
+				c.Message = message
+				changed = true
+			}
+
+			return changed
+		}
+	}
+	schema.Status.Conditions = append(schema.Status.Conditions, SchemaCondition{Type: condition, Status: status, Message: message})
+	return true
+}
+
+// GetSchemaName returns the name of the schema on the server, which must always have the '.proto' suffix
+func (schema *Schema) GetSchemaName() string {
+	name := schema.Name
+	if schema.Spec.Name != "" {
+		name = schema.Spec.Name
+	}
+	if !strings.HasSuffix(name, ".proto") {
+		name += ".proto"
+	}
+	return name
+}
+
+// SetCondition set condition to status
+func (task *Task) SetCondition(condition TaskConditionType, status metav1.ConditionStatus, message string) bool {
+	changed := false
+	for idx := range task.Status.Conditions {
+		c := &task.Status.Conditions[idx]
+		if c.Type == condition {
+			if c.Status != status {
+				c.Status = status
+				changed = true
+			}
+			if c.Message != message {
+				c.Message = message
+				changed = true
+			}
+
+			return changed
+		}
+	}
+	task.Status.Conditions = append(task.Status.Conditions, TaskCondition{Type: condition, Status: status, Message: message})
+	return true
+}
+
+func (task *Task) GetTaskName() string {
+	if task.Spec.Name != "" {
+		return task.Spec.Name
+	}
+	return task.Name
+}
+
+// IsCompleted returns true once the diagnostics have been collected, or the collection has failed
+func (d *Diagnostics) IsCompleted() bool {
+	return d.Status.Phase == DiagnosticsSucceeded || d.Status.Phase == DiagnosticsFailed
+}
+
+// GetClaimName returns the name of the PersistentVolumeClaim used to store the diagnostics archive
+func (d *Diagnostics) GetClaimName() string {
+	if d.Spec.Volume != nil && d.Spec.Volume.ClaimName != "" {
+		return d.Spec.Volume.ClaimName
+	}
+	return d.Name
+}
+
+// SetCondition set condition to status
+func (schedule *BackupSchedule) SetCondition(condition BackupScheduleConditionType, status metav1.ConditionStatus, message string) bool {
+	changed := false
+	for idx := range schedule.Status.Conditions {
+		c := &schedule.Status.Conditions[idx]
+		if c.Type == condition {
+			if c.Status != status {
+				c.Status = status
+				changed = true
+			}
+			if c.Message != message {
+				c.Message = message
+				changed = true
+			}
+
+			return changed
+		}
+	}
+	schedule.Status.Conditions = append(schedule.Status.Conditions, BackupScheduleCondition{Type: condition, Status: status, Message: message})
+	return true
+}
+
+// IsCompleted returns true if the Backup has either succeeded or failed
+func (b *Backup) IsCompleted() bool {
+	return b.Status.Phase == BackupSucceeded || b.Status.Phase == BackupFailed
+}
+
+// ObjectKey returns the key of the archive created by the named Backup
+func (s *BackupS3Spec) ObjectKey(backup string) string {
+	return path.Join(s.Prefix, backup+".zip")
+}
+
+// S3 returns the S3 location the Backup archive is uploaded to, or nil if the archive is only stored on a volume
+func (b *Backup) S3() *BackupS3Spec {
+	if b.Spec.Target == nil {
+		return nil
+	}
+	return b.Spec.Target.S3
+}
+
+// S3 returns the S3 location the Backup archive is downloaded from, or nil if the archive is read from a volume
+func (r *Restore) S3() *BackupS3Spec {
+	if r.Spec.Source == nil {
+		return nil
+	}
+	return r.Spec.Source.S3
+}
+
+// Volume returns the volume source the Backup archive is read from, or nil if the archive is read from the Backup's
+// PersistentVolumeClaim or downloaded
+func (r *Restore) Volume() *RestoreVolumeSourceSpec {
+	if r.Spec.Source == nil {
+		return nil
+	}
+	return r.Spec.Source.Volume
+}
+
+// ArchivePath returns the path of the backup archive relative to the root of the volume it's stored on
+func (r *Restore) ArchivePath() string {
+	if volume := r.Volume(); volume != nil && volume.Path != "" {
+		return strings.TrimPrefix(path.Clean(volume.Path), "/")
+	}
+	return path.Join(r.Spec.Backup, r.Spec.Backup+".zip")
+}
+
+// GetSecretKey returns the key within the Secret that contains the encryption key
+func (e *BackupEncryptionSpec) GetSecretKey() string {
+	if e.SecretKey == "" {
+		return "key"
+	}
+	return e.SecretKey
+}
@@ -2,7 +2,6 @@ package controllers
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"sync"
 	"time"
@@ -84,20 +83,27 @@ func (r *infinispanRequest) autoscalerLoop() {
 		for _, pItem := range podList.Items {
 			podName := pItem.Name
 
-			ispnClient, err := NewInfinispanForPod(r.ctx, podName, &ispn, r.kubernetes)
+			ispnClient, err := r.clients.NewInfinispanForPod(r.ctx, podName, &ispn)
 			if err != nil {
 				log.Error(err, "unable to create Infinispan client", "podName", podName)
 				skipAutoscale = true
 				break
 			}
 
+			// Retrieve all of the pod's metrics once, as the payload is large
+			samples, err := ispnClient.Metrics().Get()
+			if err != nil {
+				log.Error(err, "Unable to get metrics for pod", "podName", podName)
+				continue
+			}
+
 			if metricMinPodNum == 0 {
-				metricMinPodNum, err = getMetricMinPodNum(podName, ispnClient.Metrics())
+				metricMinPodNum, err = getMetricMinPodNum(samples)
 				if err != nil {
 					log.Error(err, "Unable to get metricMinPodNum for pod", "podName", podName)
 				}
 			}
-			err = getMetricDataMemoryPercentUsage(&metricDataMemoryPercentUsed, podName, ispnClient.Metrics())
+			err = getMetricDataMemoryPercentUsage(&metricDataMemoryPercentUsed, podName, samples)
 			if err != nil {
 				log.Error(err, "Unable to get DataMemoryUsed for pod", "podName", podName)
 			}
@@ -109,60 +115,62 @@ func (r *infinispanRequest) autoscalerLoop() {
 	}
 }
 
-// getMetricMinPodNum get the minimum number of nodes required to avoid data lost
-func getMetricMinPodNum(podName string, metrics api.Metrics) (int32, error) {
-	res, err := metrics.Get("vendor/cache_manager_default_cache_default_cluster_cache_stats_required_minimum_number_of_nodes")
-	if err != nil {
-		return 0, err
+// metricQuery identifies a metric. Depending on the server version and configuration, the cache manager and cache names
+// are either part of the metric name or exposed as labels, so each metric is queried using all of its known forms
+type metricQuery struct {
+	name   string
+	labels map[string]string
+}
+
+var (
+	metricRequiredMinimumNumberOfNodes = []metricQuery{
+		{name: "vendor_cache_manager_default_cache_default_cluster_cache_stats_required_minimum_number_of_nodes"},
+		{name: "vendor_cluster_cache_stats_required_minimum_number_of_nodes", labels: map[string]string{"cache_manager": "default", "cache": "default"}},
 	}
-	log.Info(res.String())
-	minNumOfNodes := map[string]int32{}
-	err = json.Unmarshal(res.Bytes(), &minNumOfNodes)
-	if err != nil {
-		return 0, err
+	metricDataMemoryUsed = []metricQuery{
+		{name: "vendor_cache_manager_default_cache_container_stats_data_memory_used"},
+		{name: "vendor_cache_container_stats_data_memory_used", labels: map[string]string{"cache_manager": "default"}},
 	}
-	// We expect 1 value
-	if len(minNumOfNodes) != 1 {
-		return 0, fmt.Errorf("more than 1 value returned for minNumOfNodes")
+	metricEvictionSize = []metricQuery{
+		{name: "vendor_cache_manager_default_cache_default_configuration_eviction_size"},
+		{name: "vendor_configuration_eviction_size", labels: map[string]string{"cache_manager": "default", "cache": "default"}},
 	}
-	ret := int32(0)
-	for _, v := range minNumOfNodes {
-		ret = v
+)
+
+// getMetricValue returns the value of the first sample matching one of the provided queries
+func getMetricValue(samples []api.MetricSample, queries []metricQuery) (float64, error) {
+	for _, q := range queries {
+		for _, s := range samples {
+			if s.Name == q.name && s.Matches(q.labels) {
+				return s.Value, nil
+			}
+		}
 	}
-	return ret, nil
+	return 0, fmt.Errorf("metric '%s' not found", queries[0].name)
 }
 
-func getMetricDataMemoryPercentUsage(m *map[string]int, podName string, metrics api.Metrics) error {
-	res, err := metrics.Get("vendor/cache_manager_default_cache_container_stats_data_memory_used")
+// getMetricMinPodNum get the minimum number of nodes required to avoid data lost
+func getMetricMinPodNum(samples []api.MetricSample) (int32, error) {
+	minNumOfNodes, err := getMetricValue(samples, metricRequiredMinimumNumberOfNodes)
 	if err != nil {
-		return err
-	}
-	log.Info(res.String())
-	usedMap := map[string]int{}
-	err = json.Unmarshal(res.Bytes(), &usedMap)
-	if err != nil || len(usedMap) < 1 {
-		return err
-	}
-	var used int
-	for _, v := range usedMap {
-		used = v
-		break
+		return 0, err
 	}
+	return int32(minNumOfNodes), nil
+}
 
-	res, err = metrics.Get("vendor/cache_manager_default_cache_default_configuration_eviction_size")
+// getMetricDataMemoryPercentUsage records the percentage of the default cache's eviction size used by the pod in m
+func getMetricDataMemoryPercentUsage(m *map[string]int, podName string, samples []api.MetricSample) error {
+	used, err := getMetricValue(samples, metricDataMemoryUsed)
 	if err != nil {
 		return err
 	}
-	log.Info(res.String())
-	totalMap := map[string]int{}
-	err = json.Unmarshal(res.Bytes(), &totalMap)
-	if err != nil || len(totalMap) < 1 {
+
+	total, err := getMetricValue(samples, metricEvictionSize)
+	if err != nil {
 		return err
 	}
-	var total int
-	for _, v := range totalMap {
-		total = v
-		break
+	if total <= 0 {
+		return fmt.Errorf("eviction size not configured for the default cache")
 	}
 
 	(*m)["dataMemPercentUsage;node="+podName] = int(used * 100 / total)
@@ -0,0 +1,30 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutoscaleMetrics(t *testing.T) {
+	// The same samples are used for all of the metrics of a pod
+	samples := []api.MetricSample{
+		{Name: "vendor_cluster_cache_stats_required_minimum_number_of_nodes", Labels: map[string]string{"cache_manager": "default", "cache": "default"}, Value: 2},
+		{Name: "vendor_cache_manager_default_cache_container_stats_data_memory_used", Value: 25},
+		{Name: "vendor_configuration_eviction_size", Labels: map[string]string{"cache_manager": "default", "cache": "other"}, Value: 1},
+		{Name: "vendor_configuration_eviction_size", Labels: map[string]string{"cache_manager": "default", "cache": "default"}, Value: 200},
+	}
+
+	minPodNum, err := getMetricMinPodNum(samples)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), minPodNum)
+
+	usage := map[string]int{}
+	assert.NoError(t, getMetricDataMemoryPercentUsage(&usage, "example-0", samples))
+	assert.Equal(t, map[string]int{"dataMemPercentUsage;node=example-0": 12}, usage)
+
+	_, err = getMetricMinPodNum(samples[1:])
+	assert.EqualError(t, err, "metric 'vendor_cache_manager_default_cache_default_cluster_cache_stats_required_minimum_number_of_nodes' not found")
+	assert.EqualError(t, getMetricDataMemoryPercentUsage(&usage, "example-0", samples[:3]), "metric 'vendor_cache_manager_default_cache_default_configuration_eviction_size' not found")
+}
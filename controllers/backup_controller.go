@@ -2,10 +2,15 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
+	v1 "github.com/infinispan/infinispan-operator/api/v1"
 	"github.com/infinispan/infinispan-operator/api/v2alpha1"
 	"github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/encryption"
 	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
 	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
 	corev1 "k8s.io/api/core/v1"
@@ -15,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -34,7 +40,10 @@ type BackupReconciler struct {
 type backupResource struct {
 	instance *v2alpha1.Backup
 	client   client.Client
+	kube     *kube.Kubernetes
+	clients  *InfinispanClientFactory
 	scheme   *runtime.Scheme
+	eventRec record.EventRecorder
 	ctx      context.Context
 }
 
@@ -52,7 +61,10 @@ func (r *BackupReconciler) ResourceInstance(ctx context.Context, key types.Names
 	return &backupResource{
 		instance: instance,
 		client:   r.Client,
+		kube:     ctrl.Kube,
+		clients:  ctrl.Clients,
 		scheme:   ctrl.Scheme,
+		eventRec: ctrl.EventRec,
 		ctx:      ctx,
 	}, nil
 }
@@ -61,7 +73,7 @@ func (r *BackupReconciler) Type() client.Object {
 	return &v2alpha1.Backup{}
 }
 
-func (r *backupResource) AsMeta() metav1.Object {
+func (r *backupResource) AsMeta() client.Object {
 	return r.instance
 }
 
@@ -122,6 +134,20 @@ func (r *backupResource) update(mutate func()) (bool, error) {
 }
 
 func (r *backupResource) Init() (*zeroCapacitySpec, error) {
+	if r.instance.S3() != nil {
+		// The archive is uploaded once the backup has completed, so there's no need to persist it on a PVC
+		return &zeroCapacitySpec{
+			Volume: zeroCapacityVolumeSpec{
+				MountPath: BackupDataMountPath,
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				},
+			},
+			Container: r.instance.Spec.Container,
+			PodLabels: BackupPodLabels(r.instance.Name, r.instance.Spec.Cluster),
+		}, nil
+	}
+
 	err := r.getOrCreatePvc()
 	if err != nil {
 		return nil, err
@@ -144,6 +170,22 @@ func (r *backupResource) Init() (*zeroCapacitySpec, error) {
 	}, nil
 }
 
+// Bootstrap validates the spec, so that an invalid spec fails the Backup before the zero-capacity pod is created
+func (r *backupResource) Bootstrap() (bool, error) {
+	if err := validateBackupSpec(&r.instance.Spec); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// validateBackupSpec ensures that the spec can be executed, it's also applied to the template of a BackupSchedule
+func validateBackupSpec(spec *v2alpha1.BackupSpec) error {
+	if spec.IncludeKubernetesResources && spec.Encryption == nil {
+		return fmt.Errorf("'spec.encryption' must be defined when 'spec.includeKubernetesResources' is true, as the Kubernetes resources include the user identity Secret")
+	}
+	return nil
+}
+
 func (r *backupResource) getOrCreatePvc() error {
 	pvc := &corev1.PersistentVolumeClaim{}
 	err := r.client.Get(r.ctx, types.NamespacedName{
@@ -161,16 +203,28 @@ func (r *backupResource) getOrCreatePvc() error {
 	}
 
 	volumeSpec := r.instance.Spec.Volume
+	estimate, estimateErr := r.estimateSize()
+	if estimateErr != nil {
+		r.eventRec.Event(r.instance, corev1.EventTypeWarning, "BackupSizeUnknown", fmt.Sprintf("Unable to estimate backup size: %v", estimateErr))
+	}
+
 	var storage resource.Quantity
 	if volumeSpec.Storage == nil {
-		// TODO calculate based upon number of Pods in cluster
-		// ISPN- Utilise backup size estimate
-		storage = constants.DefaultPVSize
+		headroom := int32(DefaultBackupHeadroomPercent)
+		if volumeSpec.HeadroomPercent != nil {
+			headroom = *volumeSpec.HeadroomPercent
+		}
+		storage = backupPvcSize(estimate, headroom)
 	} else {
 		storage, err = resource.ParseQuantity(*volumeSpec.Storage)
 		if err != nil {
 			return err
 		}
+		if estimateErr == nil && storage.Value() < estimate {
+			estimated := resource.NewQuantity(estimate, resource.BinarySI)
+			r.eventRec.Event(r.instance, corev1.EventTypeWarning, "BackupStorageTooSmall",
+				fmt.Sprintf("Requested storage %s is smaller than the estimated backup size %s, the backup may fail", storage.String(), estimated.String()))
+		}
 	}
 
 	// TODO add labels
@@ -200,6 +254,23 @@ func (r *backupResource) getOrCreatePvc() error {
 	return nil
 }
 
+// estimateSize returns the estimated number of bytes required to store the backup archive
+func (r *backupResource) estimateSize() (int64, error) {
+	infinispan := &v1.Infinispan{}
+	if err := r.client.Get(r.ctx, types.NamespacedName{Namespace: r.instance.Namespace, Name: r.instance.Spec.Cluster}, infinispan); err != nil {
+		return 0, err
+	}
+	inputs, err := collectBackupSizeInputs(r.ctx, infinispan, r.kube, r.clients)
+	if err != nil {
+		return 0, err
+	}
+	return estimateBackupSize(inputs, r.instance.Spec.Resources), nil
+}
+
+func (r *backupResource) PreExec(client api.Infinispan) error {
+	return nil
+}
+
 func (r *backupResource) Exec(client api.Infinispan) error {
 	instance := r.instance
 	var resources api.BackupRestoreResources
@@ -218,6 +289,11 @@ func (r *backupResource) Exec(client api.Infinispan) error {
 		Directory: BackupDataMountPath,
 		Resources: resources,
 	}
+	if _, err := r.update(func() {
+		instance.Status.StartTime = &metav1.Time{Time: time.Now()}
+	}); err != nil {
+		return err
+	}
 	return client.Container().Backups().Create(instance.Name, config)
 }
 
@@ -230,3 +306,164 @@ func (r *backupResource) ExecStatus(client api.Infinispan) (zeroCapacityPhase, e
 	}
 	return zeroCapacityPhase(status), nil
 }
+
+func (r *backupResource) Cancel(client api.Infinispan) (bool, error) {
+	name := r.instance.Name
+	backups := client.Container().Backups()
+	if err := backups.Cancel(name); err != nil {
+		return false, err
+	}
+
+	// The backup no longer exists on the server once it has stopped, so Status returns an error
+	if status, err := backups.Status(name); err == nil && status == api.StatusRunning {
+		return false, nil
+	}
+
+	// Remove any partial archive so that it's not mistaken for a completed backup
+	_, err := r.kube.ExecWithOptions(kube.ExecOptions{
+		Container: InfinispanContainer,
+		Command:   []string{"rm", "-rf", fmt.Sprintf("%s/%s", BackupDataMountPath, name)},
+		Namespace: r.instance.Namespace,
+		PodName:   name,
+	})
+	if err != nil {
+		return false, fmt.Errorf("unable to remove partial backup archive: %w", err)
+	}
+	return true, nil
+}
+
+// Finalize removes the objects uploaded to S3 when the target's deletion policy is Delete. The objects are retained if
+// the credentials Secret no longer exists, so that deletion of the Backup is not blocked
+func (r *backupResource) Finalize() error {
+	backup := r.instance
+	s3Spec := backup.S3()
+	if s3Spec == nil || backup.Spec.Target.DeletionPolicy != v2alpha1.BackupDeletionPolicyDelete {
+		return nil
+	}
+	if err := deleteS3Objects(r.ctx, r.client, backup.Namespace, s3Spec, backup.Name); err != nil {
+		if errors.IsNotFound(err) {
+			r.eventRec.Event(backup, corev1.EventTypeWarning, "S3ObjectsRetained", fmt.Sprintf("Unable to remove '%s': %s", s3Location(s3Spec, s3Spec.ObjectKey(backup.Name)), err))
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// PostExec encrypts the archive, writes the manifest and uploads the archive if required. Errors caused by the
+// zero-capacity pod or the S3 endpoint being temporarily unavailable are transient, so every step must be idempotent
+func (r *backupResource) PostExec(client api.Infinispan) error {
+	backup := r.instance
+	pod := types.NamespacedName{Namespace: backup.Namespace, Name: backup.Name}
+	archivePath := backupArchivePath(backup.Name)
+	manifest := &backupManifest{
+		Backup:         backup.Name,
+		StartTime:      backup.Status.StartTime,
+		CompletionTime: metav1.Now(),
+	}
+
+	// Encrypt before uploading so that the archive is never stored in plaintext outside the zero-capacity pod
+	var archiveKey []byte
+	if encryptionSpec := backup.Spec.Encryption; encryptionSpec != nil {
+		var err error
+		if archiveKey, err = encryptionKey(r.ctx, r.client, backup.Namespace, encryptionSpec); err != nil {
+			return err
+		}
+		// The archive has already been encrypted if a previous attempt failed after the encryption completed
+		header, err := archiveHeader(r.kube, pod, archivePath)
+		if err != nil {
+			return transient(err)
+		}
+		keyID := encryption.KeyID(archiveKey)
+		if header == nil {
+			if err := encryptArchive(r.kube, pod, archivePath, archiveKey); err != nil {
+				return transient(err)
+			}
+		} else if header.KeyID != keyID {
+			return &encryption.KeyMismatchError{Expected: header.KeyID, Actual: keyID}
+		}
+		manifest.Encryption = &v2alpha1.BackupEncryptionStatus{
+			Algorithm: encryption.Algorithm,
+			KeyID:     keyID,
+		}
+	}
+
+	// The digest is computed last so that it matches the archive as it's stored
+	var err error
+	if manifest.Size, manifest.Digest, err = archiveDigest(r.kube, pod, archivePath); err != nil {
+		return transient(err)
+	}
+	if manifest.Resources, err = manifestResources(client, backup.Spec.Resources); err != nil {
+		return transient(err)
+	}
+	info, err := client.Server().Info()
+	if err != nil {
+		return transient(fmt.Errorf("unable to retrieve server version: %w", err))
+	}
+	manifest.ServerVersion = info.Version
+
+	var resourcesContent []byte
+	if backup.Spec.IncludeKubernetesResources {
+		resources, err := collectKubernetesResources(r.ctx, r.client, backup.Namespace, backup.Spec.Cluster)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return err
+			}
+			return transient(err)
+		}
+		// The resources are encrypted with the archive's key as they include the user identity Secret
+		if resourcesContent, err = resources.marshal(archiveKey); err != nil {
+			return err
+		}
+		if err := writeFile(r.kube, pod, resourcesPath(archivePath), resourcesContent); err != nil {
+			return transient(fmt.Errorf("unable to write Kubernetes resources: %w", err))
+		}
+		manifest.KubernetesResources = resources.names()
+	}
+
+	if err := writeManifest(r.kube, pod, archivePath, manifest); err != nil {
+		return transient(err)
+	}
+
+	var location string
+	manifestLocation := fmt.Sprintf("pvc/%s/%s", backup.Name, strings.TrimPrefix(manifestPath(archivePath), BackupDataMountPath+"/"))
+	if s3Spec := backup.S3(); s3Spec != nil {
+		key := s3Spec.ObjectKey(backup.Name)
+		if err := uploadToS3(r.ctx, r.client, r.kube, pod, archivePath, s3Spec, key); err != nil {
+			return err
+		}
+		content, err := json.MarshalIndent(manifest, "", "  ")
+		if err != nil {
+			return err
+		}
+		manifestKey := manifestPath(key)
+		if err := putS3Object(r.ctx, r.client, backup.Namespace, s3Spec, manifestKey, content); err != nil {
+			return err
+		}
+		if resourcesContent != nil {
+			if err := putS3Object(r.ctx, r.client, backup.Namespace, s3Spec, resourcesPath(key), resourcesContent); err != nil {
+				return err
+			}
+		}
+		location = s3Location(s3Spec, key)
+		manifestLocation = s3Location(s3Spec, manifestKey)
+	}
+
+	_, err = r.update(func() {
+		backup.Status.Location = location
+		backup.Status.Encryption = manifest.Encryption
+		backup.Status.CompletionTime = &manifest.CompletionTime
+		backup.Status.Manifest = &v2alpha1.BackupManifestStatus{
+			Location:      manifestLocation,
+			ServerVersion: manifest.ServerVersion,
+			Size:          manifest.Size,
+			Digest:        manifest.Digest,
+		}
+	})
+	return transient(err)
+}
+
+// backupArchivePath returns the path of the archive created by the named Backup on the zero-capacity pod
+func backupArchivePath(backup string) string {
+	return fmt.Sprintf("%[1]s/%[2]s/%[2]s.zip", BackupDataMountPath, backup)
+}
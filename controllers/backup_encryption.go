@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/pkg/encryption"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// encryptionKey loads the key referenced by the spec, returning an error if the key is not a valid AES-256 key
+func encryptionKey(ctx context.Context, c client.Client, namespace string, spec *v2alpha1.BackupEncryptionSpec) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: spec.SecretName}, secret); err != nil {
+		return nil, fmt.Errorf("unable to load encryption Secret '%s': %w", spec.SecretName, err)
+	}
+	key, ok := secret.Data[spec.GetSecretKey()]
+	if !ok {
+		return nil, fmt.Errorf("key '%s' not found in encryption Secret '%s'", spec.GetSecretKey(), spec.SecretName)
+	}
+	if len(key) != encryption.KeySize {
+		return nil, fmt.Errorf("key '%s' in encryption Secret '%s' must contain %d bytes", spec.GetSecretKey(), spec.SecretName, encryption.KeySize)
+	}
+	return key, nil
+}
+
+// encryptArchive replaces the archive on the zero-capacity pod with its encrypted form
+func encryptArchive(k8s *kube.Kubernetes, pod types.NamespacedName, archivePath string, key []byte) error {
+	encryptedPath := archivePath + ".enc"
+	err := transformArchive(k8s, pod, archivePath, encryptedPath, func(dst io.Writer, src io.Reader) error {
+		return encryption.Encrypt(dst, src, key)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to encrypt archive '%s': %w", archivePath, err)
+	}
+
+	_, err = k8s.ExecWithOptions(kube.ExecOptions{
+		Container: InfinispanContainer,
+		Command:   []string{"mv", encryptedPath, archivePath},
+		Namespace: pod.Namespace,
+		PodName:   pod.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to replace archive '%s': %w", archivePath, err)
+	}
+	return nil
+}
+
+// decryptArchive writes the decrypted content of the archive at archivePath to decryptedPath on the zero-capacity pod
+func decryptArchive(k8s *kube.Kubernetes, pod types.NamespacedName, archivePath, decryptedPath string, key []byte) error {
+	err := transformArchive(k8s, pod, archivePath, decryptedPath, func(dst io.Writer, src io.Reader) error {
+		return encryption.Decrypt(dst, src, key)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to decrypt archive '%s': %w", archivePath, err)
+	}
+	return nil
+}
+
+// archiveHeader returns the encryption header of the archive, or nil if the archive is not encrypted
+func archiveHeader(k8s *kube.Kubernetes, pod types.NamespacedName, archivePath string) (*encryption.Header, error) {
+	stdout, err := k8s.ExecWithOptions(kube.ExecOptions{
+		Container: InfinispanContainer,
+		Command:   []string{"head", "-c", fmt.Sprint(encryption.HeaderSize), archivePath},
+		Namespace: pod.Namespace,
+		PodName:   pod.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read archive '%s': %w", archivePath, err)
+	}
+	if !encryption.IsEncrypted(stdout.Bytes()) {
+		return nil, nil
+	}
+	return encryption.ReadHeader(&stdout)
+}
+
+// transformArchive streams the file at src on the zero-capacity pod through transform and writes the output to dst
+// on the same pod. The content is never buffered in its entirety by the operator.
+func transformArchive(k8s *kube.Kubernetes, pod types.NamespacedName, src, dst string, transform func(dst io.Writer, src io.Reader) error) error {
+	srcReader, srcWriter := io.Pipe()
+	go func() {
+		_, err := k8s.ExecWithOptions(kube.ExecOptions{
+			Container: InfinispanContainer,
+			Command:   []string{"cat", src},
+			Namespace: pod.Namespace,
+			PodName:   pod.Name,
+			Stdout:    srcWriter,
+		})
+		_ = srcWriter.CloseWithError(err)
+	}()
+
+	dstReader, dstWriter := io.Pipe()
+	writeErr := make(chan error, 1)
+	go func() {
+		_, err := k8s.ExecWithOptions(kube.ExecOptions{
+			Container: InfinispanContainer,
+			Command:   []string{"sh", "-c", `cat > "$0"`, dst},
+			Namespace: pod.Namespace,
+			PodName:   pod.Name,
+			Stdin:     dstReader,
+		})
+		// Unblock the transform if the write failed before all content was consumed
+		_ = dstReader.CloseWithError(err)
+		writeErr <- err
+	}()
+
+	err := transform(dstWriter, srcReader)
+	_ = srcReader.CloseWithError(err)
+	_ = dstWriter.CloseWithError(err)
+	if wErr := <-writeErr; err == nil {
+		err = wErr
+	}
+	return err
+}
@@ -0,0 +1,252 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/version"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// The name used in a manifest to indicate that all resources of a given type are included. Manifests no longer use
+	// the wildcard, however it's still supported so that older manifests can be verified
+	manifestWildcard = "*"
+	// The prefix of the templates provided by the server
+	serverTemplatePrefix = "org.infinispan."
+)
+
+// backupManifest describes the content of a backup archive and is stored alongside the archive
+type backupManifest struct {
+	Backup string `json:"backup"`
+	// The resources included in the archive, retrieved from the server once the backup has completed
+	Resources      api.BackupRestoreResources       `json:"resources"`
+	ServerVersion  string                           `json:"serverVersion"`
+	Size           int64                            `json:"size"`
+	Digest         string                           `json:"digest"`
+	StartTime      *metav1.Time                     `json:"startTime,omitempty"`
+	CompletionTime metav1.Time                      `json:"completionTime"`
+	Encryption     *v2alpha1.BackupEncryptionStatus `json:"encryption,omitempty"`
+	// The Kubernetes resources stored alongside the archive, e.g. "Infinispan/example"
+	KubernetesResources []string `json:"kubernetesResources,omitempty"`
+}
+
+// manifestPath returns the path of the manifest stored alongside the archive
+func manifestPath(archivePath string) string {
+	return strings.TrimSuffix(archivePath, ".zip") + ".manifest.json"
+}
+
+// manifestResources returns the resources included in a backup. Resource types that were not explicitly requested are
+// included in their entirety, so their names are retrieved from the server. The names reflect the live state of the
+// server once the backup has completed, rather than the content of the archive, so resources created or removed while
+// the backup was in progress may be listed incorrectly.
+func manifestResources(client api.Infinispan, spec *v2alpha1.BackupResources) (api.BackupRestoreResources, error) {
+	if spec == nil {
+		spec = &v2alpha1.BackupResources{}
+	}
+
+	resolve := func(requested []string, list func() ([]string, error)) ([]string, error) {
+		if len(requested) > 0 && !contains(requested, manifestWildcard) {
+			return requested, nil
+		}
+		names, err := list()
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(names)
+		return names, nil
+	}
+
+	var err error
+	resources := api.BackupRestoreResources{}
+	if resources.Caches, err = resolve(spec.Caches, client.Caches().Names); err != nil {
+		return resources, fmt.Errorf("unable to retrieve cache names: %w", err)
+	}
+	if resources.Counters, err = resolve(spec.Counters, client.Counters().Names); err != nil {
+		return resources, fmt.Errorf("unable to retrieve counter names: %w", err)
+	}
+	if resources.ProtoSchemas, err = resolve(spec.ProtoSchemas, func() ([]string, error) {
+		schemas, err := client.Schemas().List()
+		names := make([]string, len(schemas))
+		for i, schema := range schemas {
+			names[i] = schema.Name
+		}
+		return names, err
+	}); err != nil {
+		return resources, fmt.Errorf("unable to retrieve schema names: %w", err)
+	}
+	if resources.Tasks, err = resolve(spec.Tasks, func() ([]string, error) {
+		tasks, err := client.Tasks().List(api.TaskTypeUser)
+		names := make([]string, len(tasks))
+		for i, task := range tasks {
+			names[i] = task.Name
+		}
+		return names, err
+	}); err != nil {
+		return resources, fmt.Errorf("unable to retrieve task names: %w", err)
+	}
+	if resources.Templates, err = resolve(spec.Templates, func() ([]string, error) {
+		templates, err := client.Caches().TemplateNames()
+		// The templates provided by the server are not included in backups
+		var names []string
+		for _, template := range templates {
+			if !strings.HasPrefix(template, serverTemplatePrefix) {
+				names = append(names, template)
+			}
+		}
+		return names, err
+	}); err != nil {
+		return resources, fmt.Errorf("unable to retrieve template names: %w", err)
+	}
+	return resources, nil
+}
+
+// archiveDigest returns the size and SHA-256 digest of the archive on the zero-capacity pod
+func archiveDigest(k8s *kube.Kubernetes, pod types.NamespacedName, archivePath string) (int64, string, error) {
+	stdout, err := k8s.ExecWithOptions(kube.ExecOptions{
+		Container: InfinispanContainer,
+		Command:   []string{"sh", "-c", `stat -c %s "$0" && sha256sum "$0"`, archivePath},
+		Namespace: pod.Namespace,
+		PodName:   pod.Name,
+	})
+	if err != nil {
+		return 0, "", fmt.Errorf("unable to compute digest of archive '%s': %w", archivePath, err)
+	}
+
+	fields := strings.Fields(stdout.String())
+	if len(fields) < 2 {
+		return 0, "", fmt.Errorf("unexpected output computing digest of archive '%s': %s", archivePath, stdout.String())
+	}
+	size, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("unable to parse size of archive '%s': %w", archivePath, err)
+	}
+	return size, "sha256:" + fields[1], nil
+}
+
+// writeManifest stores the manifest alongside the archive on the zero-capacity pod
+func writeManifest(k8s *kube.Kubernetes, pod types.NamespacedName, archivePath string, manifest *backupManifest) error {
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := manifestPath(archivePath)
+	if err := writeFile(k8s, pod, path, content); err != nil {
+		return fmt.Errorf("unable to write manifest '%s': %w", path, err)
+	}
+	return nil
+}
+
+// writeFile writes content to path on the zero-capacity pod, replacing any existing file
+func writeFile(k8s *kube.Kubernetes, pod types.NamespacedName, path string, content []byte) error {
+	_, err := k8s.ExecWithOptions(kube.ExecOptions{
+		Container: InfinispanContainer,
+		Command:   []string{"sh", "-c", `cat > "$0"`, path},
+		Namespace: pod.Namespace,
+		PodName:   pod.Name,
+		Stdin:     bytes.NewReader(content),
+	})
+	return err
+}
+
+// readManifest returns the manifest stored alongside the archive on the zero-capacity pod, or nil if the archive was
+// created without a manifest
+func readManifest(k8s *kube.Kubernetes, pod types.NamespacedName, archivePath string) (*backupManifest, error) {
+	path := manifestPath(archivePath)
+	stdout, err := k8s.ExecWithOptions(kube.ExecOptions{
+		Container: InfinispanContainer,
+		Command:   []string{"sh", "-c", `if [ -f "$0" ]; then cat "$0"; fi`, path},
+		Namespace: pod.Namespace,
+		PodName:   pod.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read manifest '%s': %w", path, err)
+	}
+	if stdout.Len() == 0 {
+		return nil, nil
+	}
+	return parseManifest(stdout.Bytes())
+}
+
+func parseManifest(content []byte) (*backupManifest, error) {
+	manifest := &backupManifest{}
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return nil, fmt.Errorf("unable to parse backup manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// verifyArchive returns an error if the size or digest of the archive differs from the manifest
+func (m *backupManifest) verifyArchive(size int64, digest string) error {
+	if m.Size != size {
+		return fmt.Errorf("backup archive size %d does not match the size %d recorded in the manifest", size, m.Size)
+	}
+	if m.Digest != digest {
+		return fmt.Errorf("backup archive digest '%s' does not match the digest '%s' recorded in the manifest", digest, m.Digest)
+	}
+	return nil
+}
+
+// verifyServerVersion returns an error if the archive was created by a more recent server version than the one
+// restoring it. Versions that cannot be parsed are not verified.
+func (m *backupManifest) verifyServerVersion(serverVersion string) error {
+	backupVersion, err := version.FromString(m.ServerVersion)
+	if err != nil {
+		return nil
+	}
+	restoreVersion, err := version.FromString(serverVersion)
+	if err != nil {
+		return nil
+	}
+	// Only the major and minor versions determine the backup format
+	backupVersion.Patch, restoreVersion.Patch = 0, 0
+	if restoreVersion.Compare(backupVersion) < 0 {
+		return fmt.Errorf("backup created by server version '%s' cannot be restored by older server version '%s'", m.ServerVersion, serverVersion)
+	}
+	return nil
+}
+
+// verifyResources returns an error if any of the requested resources are not contained in the archive
+func (m *backupManifest) verifyResources(resources *v2alpha1.RestoreResources) error {
+	if resources == nil {
+		return nil
+	}
+
+	var missing []string
+	check := func(resourceType string, requested, included []string) {
+		if contains(included, manifestWildcard) {
+			return
+		}
+		for _, name := range requested {
+			if name != manifestWildcard && !contains(included, name) {
+				missing = append(missing, fmt.Sprintf("%s '%s'", resourceType, name))
+			}
+		}
+	}
+	check("cache", resources.Caches, m.Resources.Caches)
+	check("counter", resources.Counters, m.Resources.Counters)
+	check("schema", resources.ProtoSchemas, m.Resources.ProtoSchemas)
+	check("task", resources.Tasks, m.Resources.Tasks)
+	check("template", resources.Templates, m.Resources.Templates)
+	if len(missing) > 0 {
+		return fmt.Errorf("backup archive does not contain %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/fake"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManifestPath(t *testing.T) {
+	assert.Equal(t, "/opt/infinispan/backups/b/b.manifest.json", manifestPath("/opt/infinispan/backups/b/b.zip"))
+	assert.Equal(t, "prefix/b.manifest.json", manifestPath("prefix/b.zip"))
+}
+
+func TestManifestVerifyArchive(t *testing.T) {
+	manifest := &backupManifest{Size: 10, Digest: "sha256:abc"}
+	assert.NoError(t, manifest.verifyArchive(10, "sha256:abc"))
+	assert.EqualError(t, manifest.verifyArchive(11, "sha256:abc"), "backup archive size 11 does not match the size 10 recorded in the manifest")
+	assert.EqualError(t, manifest.verifyArchive(10, "sha256:def"), "backup archive digest 'sha256:def' does not match the digest 'sha256:abc' recorded in the manifest")
+}
+
+func TestManifestVerifyServerVersion(t *testing.T) {
+	manifest := &backupManifest{ServerVersion: "Infinispan 'Triskaidekaphobia' 13.0.10.Final"}
+	assert.NoError(t, manifest.verifyServerVersion("Infinispan 'Triskaidekaphobia' 13.0.1.Final"))
+	assert.NoError(t, manifest.verifyServerVersion("Infinispan 'Flying Saucer' 14.0.0.Final"))
+	assert.Error(t, manifest.verifyServerVersion("Infinispan 'Corona Extra' 12.1.7.Final"))
+	// Unknown versions are not verified
+	assert.NoError(t, manifest.verifyServerVersion("unknown"))
+}
+
+func TestManifestVerifyResources(t *testing.T) {
+	manifest := &backupManifest{
+		Resources: api.BackupRestoreResources{
+			Caches:    []string{"cache1", "cache2"},
+			Counters:  []string{},
+			Templates: []string{manifestWildcard},
+		},
+	}
+	assert.NoError(t, manifest.verifyResources(nil))
+	assert.NoError(t, manifest.verifyResources(&v2alpha1.RestoreResources{
+		Caches:    []string{"cache1"},
+		Templates: []string{"any-template"},
+	}))
+	assert.EqualError(t, manifest.verifyResources(&v2alpha1.RestoreResources{
+		Caches:   []string{"cache1", "cache3"},
+		Counters: []string{"counter"},
+	}), "backup archive does not contain cache 'cache3', counter 'counter'")
+}
+
+func TestManifestResources(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	server.AddCache("cache2", "{}")
+	server.AddCache("cache1", "{}")
+	server.Update(func(s *fake.State) {
+		s.Templates["org.infinispan.DIST_SYNC"] = "{}"
+		s.Templates["template"] = "{}"
+		s.Counters["counter"] = &fake.Counter{}
+		s.Schemas["schema.proto"] = &fake.Schema{}
+		s.Tasks["task.js"] = &fake.Task{}
+	})
+
+	// All resources are listed when none are requested, excluding the templates provided by the server
+	resources, err := manifestResources(server.Infinispan(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, api.BackupRestoreResources{
+		Caches:       []string{"cache1", "cache2"},
+		Templates:    []string{"template"},
+		Counters:     []string{"counter"},
+		ProtoSchemas: []string{"schema.proto"},
+		Tasks:        []string{"task.js"},
+	}, resources)
+
+	resources, err = manifestResources(server.Infinispan(), &v2alpha1.BackupResources{
+		Caches:    []string{"cache1"},
+		Templates: []string{manifestWildcard},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cache1"}, resources.Caches)
+	assert.Equal(t, []string{"template"}, resources.Templates)
+}
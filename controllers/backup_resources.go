@@ -0,0 +1,203 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	v1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/pkg/encryption"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// kubernetesResources are the Kubernetes resources required to recreate an Infinispan cluster, which are stored
+// alongside the backup archive when spec.includeKubernetesResources is true
+type kubernetesResources struct {
+	Infinispan *v1.Infinispan     `json:"infinispan"`
+	Caches     []v2alpha1.Cache   `json:"caches,omitempty"`
+	Secrets    []corev1.Secret    `json:"secrets,omitempty"`
+	ConfigMaps []corev1.ConfigMap `json:"configMaps,omitempty"`
+}
+
+// resourcesPath returns the path of the Kubernetes resources stored alongside the archive
+func resourcesPath(archivePath string) string {
+	return strings.TrimSuffix(archivePath, ".zip") + ".resources.json"
+}
+
+// collectKubernetesResources retrieves the Infinispan CR, the Cache CRs that reference it, the user identity Secret and
+// the custom ConfigMap. Cluster specific metadata and status are removed so that the resources can be recreated as is
+func collectKubernetesResources(ctx context.Context, c client.Client, namespace, cluster string) (*kubernetesResources, error) {
+	ispn := &v1.Infinispan{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: cluster}, ispn); err != nil {
+		return nil, fmt.Errorf("unable to retrieve Infinispan CR '%s': %w", cluster, err)
+	}
+	resources := &kubernetesResources{Infinispan: ispn}
+	ispn.ObjectMeta = sanitizeMeta(ispn.ObjectMeta)
+	ispn.Status = v1.InfinispanStatus{}
+
+	cacheList := &v2alpha1.CacheList{}
+	if err := c.List(ctx, cacheList, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("unable to list Cache CRs: %w", err)
+	}
+	for _, cache := range cacheList.Items {
+		if cache.Spec.ClusterName != cluster {
+			continue
+		}
+		cache.ObjectMeta = sanitizeMeta(cache.ObjectMeta)
+		cache.Status = v2alpha1.CacheStatus{}
+		resources.Caches = append(resources.Caches, cache)
+	}
+
+	if name := ispn.Spec.Security.EndpointSecretName; name != "" {
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+			if !errors.IsNotFound(err) {
+				return nil, fmt.Errorf("unable to retrieve Secret '%s': %w", name, err)
+			}
+		} else {
+			resources.Secrets = append(resources.Secrets, corev1.Secret{
+				ObjectMeta: sanitizeMeta(secret.ObjectMeta),
+				Type:       secret.Type,
+				Data:       secret.Data,
+			})
+		}
+	}
+
+	if name := ispn.Spec.ConfigMapName; name != "" {
+		configMap := &corev1.ConfigMap{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, configMap); err != nil {
+			if !errors.IsNotFound(err) {
+				return nil, fmt.Errorf("unable to retrieve ConfigMap '%s': %w", name, err)
+			}
+		} else {
+			resources.ConfigMaps = append(resources.ConfigMaps, corev1.ConfigMap{
+				ObjectMeta: sanitizeMeta(configMap.ObjectMeta),
+				Data:       configMap.Data,
+				BinaryData: configMap.BinaryData,
+			})
+		}
+	}
+	return resources, nil
+}
+
+// sanitizeMeta returns the user defined fields of meta
+func sanitizeMeta(meta metav1.ObjectMeta) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        meta.Name,
+		Namespace:   meta.Namespace,
+		Labels:      meta.Labels,
+		Annotations: meta.Annotations,
+	}
+}
+
+// objects returns the resources in the order that they should be created. The Infinispan CR is last so that the
+// cluster is not created before the resources that it references
+func (r *kubernetesResources) objects() []client.Object {
+	var objects []client.Object
+	for i := range r.Secrets {
+		objects = append(objects, &r.Secrets[i])
+	}
+	for i := range r.ConfigMaps {
+		objects = append(objects, &r.ConfigMaps[i])
+	}
+	for i := range r.Caches {
+		objects = append(objects, &r.Caches[i])
+	}
+	return append(objects, r.Infinispan)
+}
+
+// names returns the kind and name of each resource, e.g. "Infinispan/example"
+func (r *kubernetesResources) names() []string {
+	var names []string
+	for _, obj := range r.objects() {
+		names = append(names, fmt.Sprintf("%s/%s", reflect.TypeOf(obj).Elem().Name(), obj.GetName()))
+	}
+	return names
+}
+
+// rewrite moves all resources to namespace and renames the Infinispan CR to cluster. Resources named after the original
+// cluster, e.g. "<cluster>-generated-secret", are renamed so that the references in the Infinispan CR remain valid
+func (r *kubernetesResources) rewrite(namespace, cluster string) {
+	original := r.Infinispan.Name
+	rename := func(name string) string {
+		if name == original || strings.HasPrefix(name, original+"-") {
+			return cluster + strings.TrimPrefix(name, original)
+		}
+		return name
+	}
+
+	for _, obj := range r.objects() {
+		obj.SetName(rename(obj.GetName()))
+		obj.SetNamespace(namespace)
+	}
+
+	spec := &r.Infinispan.Spec
+	if spec.Security.EndpointSecretName != "" {
+		spec.Security.EndpointSecretName = rename(spec.Security.EndpointSecretName)
+	}
+	if spec.ConfigMapName != "" {
+		spec.ConfigMapName = rename(spec.ConfigMapName)
+	}
+	for i := range r.Caches {
+		r.Caches[i].Spec.ClusterName = cluster
+	}
+}
+
+// create creates the resources that don't exist, existing resources are not modified
+func (r *kubernetesResources) create(ctx context.Context, c client.Client) error {
+	for _, obj := range r.objects() {
+		if err := c.Create(ctx, obj); err != nil && !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("unable to create %s '%s': %w", reflect.TypeOf(obj).Elem().Name(), obj.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// marshal returns the JSON representation of the resources encrypted with key. Resources that include Secrets are never
+// stored in plaintext, so key may only be nil if no Secrets are included
+func (r *kubernetesResources) marshal(key []byte) ([]byte, error) {
+	if key == nil && len(r.Secrets) > 0 {
+		return nil, fmt.Errorf("the Kubernetes resources include Secrets, so they must be encrypted")
+	}
+	content, err := json.MarshalIndent(r, "", "  ")
+	if err != nil || key == nil {
+		return content, err
+	}
+
+	encrypted := &bytes.Buffer{}
+	if err := encryption.Encrypt(encrypted, bytes.NewReader(content), key); err != nil {
+		return nil, fmt.Errorf("unable to encrypt Kubernetes resources: %w", err)
+	}
+	return encrypted.Bytes(), nil
+}
+
+// parseKubernetesResources parses the content created by marshal, decrypting it with key if required
+func parseKubernetesResources(content, key []byte) (*kubernetesResources, error) {
+	if encryption.IsEncrypted(content) {
+		if key == nil {
+			return nil, fmt.Errorf("the Kubernetes resources are encrypted, 'spec.encryption' must be defined")
+		}
+		decrypted := &bytes.Buffer{}
+		if err := encryption.Decrypt(decrypted, bytes.NewReader(content), key); err != nil {
+			return nil, fmt.Errorf("unable to decrypt Kubernetes resources: %w", err)
+		}
+		content = decrypted.Bytes()
+	}
+
+	resources := &kubernetesResources{}
+	if err := json.Unmarshal(content, resources); err != nil {
+		return nil, fmt.Errorf("unable to parse Kubernetes resources: %w", err)
+	}
+	if resources.Infinispan == nil {
+		return nil, fmt.Errorf("the Kubernetes resources do not contain an Infinispan CR")
+	}
+	return resources, nil
+}
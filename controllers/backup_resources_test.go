@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"bytes"
+	"testing"
+
+	v1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/pkg/encryption"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testKubernetesResources() *kubernetesResources {
+	ispn := &v1.Infinispan{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "old"}}
+	ispn.Spec.Security.EndpointSecretName = "example-generated-secret"
+	ispn.Spec.ConfigMapName = "custom-config"
+	return &kubernetesResources{
+		Infinispan: ispn,
+		Caches: []v2alpha1.Cache{{
+			ObjectMeta: metav1.ObjectMeta{Name: "example-cache", Namespace: "old"},
+			Spec:       v2alpha1.CacheSpec{ClusterName: "example", Name: "cache"},
+		}},
+		Secrets: []corev1.Secret{{
+			ObjectMeta: metav1.ObjectMeta{Name: "example-generated-secret", Namespace: "old"},
+			Data:       map[string][]byte{"identities.yaml": []byte("credentials")},
+		}},
+		ConfigMaps: []corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: "custom-config", Namespace: "old"},
+		}},
+	}
+}
+
+func TestResourcesPath(t *testing.T) {
+	assert.Equal(t, "/opt/infinispan/backups/b/b.resources.json", resourcesPath("/opt/infinispan/backups/b/b.zip"))
+}
+
+func TestKubernetesResourcesNames(t *testing.T) {
+	// The Infinispan CR must be created last
+	assert.Equal(t, []string{"Secret/example-generated-secret", "ConfigMap/custom-config", "Cache/example-cache", "Infinispan/example"}, testKubernetesResources().names())
+}
+
+func TestKubernetesResourcesRewrite(t *testing.T) {
+	resources := testKubernetesResources()
+	resources.rewrite("new", "rebuilt")
+	for _, obj := range resources.objects() {
+		assert.Equal(t, "new", obj.GetNamespace())
+	}
+	assert.Equal(t, "rebuilt", resources.Infinispan.Name)
+	assert.Equal(t, "rebuilt-generated-secret", resources.Infinispan.Spec.Security.EndpointSecretName)
+	assert.Equal(t, "rebuilt-generated-secret", resources.Secrets[0].Name)
+	// Resources that are not named after the cluster are not renamed
+	assert.Equal(t, "custom-config", resources.Infinispan.Spec.ConfigMapName)
+	assert.Equal(t, "custom-config", resources.ConfigMaps[0].Name)
+	assert.Equal(t, "rebuilt-cache", resources.Caches[0].Name)
+	assert.Equal(t, "rebuilt", resources.Caches[0].Spec.ClusterName)
+	assert.Equal(t, "cache", resources.Caches[0].Spec.Name)
+
+	// A cluster whose name is only a prefix of another resource's name is not matched
+	resources = testKubernetesResources()
+	resources.Infinispan.Name = "exam"
+	resources.rewrite("new", "rebuilt")
+	assert.Equal(t, "example-generated-secret", resources.Secrets[0].Name)
+}
+
+func TestKubernetesResourcesMarshal(t *testing.T) {
+	resources := testKubernetesResources()
+	_, err := resources.marshal(nil)
+	assert.EqualError(t, err, "the Kubernetes resources include Secrets, so they must be encrypted")
+
+	// Resources without Secrets may be stored in plaintext
+	resources.Secrets = nil
+	content, err := resources.marshal(nil)
+	assert.NoError(t, err)
+	parsed, err := parseKubernetesResources(content, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, resources, parsed)
+
+	resources = testKubernetesResources()
+	key := bytes.Repeat([]byte{1}, encryption.KeySize)
+	content, err = resources.marshal(key)
+	assert.NoError(t, err)
+	assert.True(t, encryption.IsEncrypted(content))
+	parsed, err = parseKubernetesResources(content, key)
+	assert.NoError(t, err)
+	assert.Equal(t, resources, parsed)
+
+	_, err = parseKubernetesResources(content, nil)
+	assert.EqualError(t, err, "the Kubernetes resources are encrypted, 'spec.encryption' must be defined")
+	_, err = parseKubernetesResources(content, bytes.Repeat([]byte{2}, encryption.KeySize))
+	assert.Error(t, err)
+	_, err = parseKubernetesResources([]byte("{}"), nil)
+	assert.EqualError(t, err, "the Kubernetes resources do not contain an Infinispan CR")
+}
+
+func TestBackupKubernetesResourcesRequireEncryption(t *testing.T) {
+	backup := &v2alpha1.Backup{Spec: v2alpha1.BackupSpec{IncludeKubernetesResources: true}}
+	ready, err := (&backupResource{instance: backup}).Bootstrap()
+	assert.False(t, ready)
+	assert.EqualError(t, err, "'spec.encryption' must be defined when 'spec.includeKubernetesResources' is true, as the Kubernetes resources include the user identity Secret")
+
+	backup.Spec.Encryption = &v2alpha1.BackupEncryptionSpec{SecretName: "key"}
+	ready, err = (&backupResource{instance: backup}).Bootstrap()
+	assert.True(t, ready)
+	assert.NoError(t, err)
+}
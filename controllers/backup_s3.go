@@ -0,0 +1,204 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	"github.com/infinispan/infinispan-operator/pkg/s3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	S3AccessKeyIdKey     = "AWS_ACCESS_KEY_ID"
+	S3SecretAccessKeyKey = "AWS_SECRET_ACCESS_KEY"
+	S3CACertKey          = "ca.crt"
+)
+
+// newS3Client creates a client for the S3 endpoint using the credentials stored in the spec's Secret. The endpoint's
+// certificate is verified with the CA stored in spec.caCertSecret if defined
+func newS3Client(ctx context.Context, c client.Client, namespace string, spec *v2alpha1.BackupS3Spec) (*s3.Client, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: spec.CredentialsSecret}, secret); err != nil {
+		return nil, fmt.Errorf("unable to load S3 credentials Secret '%s': %w", spec.CredentialsSecret, err)
+	}
+
+	credential := func(key string) (string, error) {
+		value, ok := secret.Data[key]
+		if !ok {
+			return "", fmt.Errorf("key '%s' not found in S3 credentials Secret '%s'", key, spec.CredentialsSecret)
+		}
+		return string(value), nil
+	}
+
+	accessKeyId, err := credential(S3AccessKeyIdKey)
+	if err != nil {
+		return nil, err
+	}
+	secretAccessKey, err := credential(S3SecretAccessKeyKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var caCert []byte
+	if spec.CACertSecret != "" {
+		caSecret := &corev1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: spec.CACertSecret}, caSecret); err != nil {
+			return nil, fmt.Errorf("unable to load S3 CA certificate Secret '%s': %w", spec.CACertSecret, err)
+		}
+		var ok bool
+		if caCert, ok = caSecret.Data[S3CACertKey]; !ok {
+			return nil, fmt.Errorf("key '%s' not found in S3 CA certificate Secret '%s'", S3CACertKey, spec.CACertSecret)
+		}
+	}
+	return s3.New(s3.Config{
+		Endpoint:        spec.Endpoint,
+		Region:          spec.Region,
+		AccessKeyID:     accessKeyId,
+		SecretAccessKey: secretAccessKey,
+		CACert:          caCert,
+	})
+}
+
+// s3Error marks err as transient if it was caused by the S3 endpoint being unavailable, or the request failing, so that
+// the operation is retried
+func s3Error(err error) error {
+	if s3.IsTransient(err) {
+		return transient(err)
+	}
+	return err
+}
+
+// s3Location returns a human readable representation of the archive's location
+func s3Location(spec *v2alpha1.BackupS3Spec, key string) string {
+	return fmt.Sprintf("s3://%s/%s", spec.Bucket, key)
+}
+
+// uploadToS3 streams the file at archivePath on the zero-capacity pod to the S3 location. The archive is read via
+// exec so that it's never necessary to buffer the archive in the operator.
+func uploadToS3(ctx context.Context, c client.Client, k8s *kube.Kubernetes, pod types.NamespacedName, archivePath string, spec *v2alpha1.BackupS3Spec, key string) error {
+	s3Client, err := newS3Client(ctx, c, pod.Namespace, spec)
+	if err != nil {
+		return err
+	}
+
+	stdout, err := k8s.ExecWithOptions(kube.ExecOptions{
+		Container: InfinispanContainer,
+		Command:   []string{"stat", "-c", "%s", archivePath},
+		Namespace: pod.Namespace,
+		PodName:   pod.Name,
+	})
+	if err != nil {
+		return transient(fmt.Errorf("unable to determine size of archive '%s': %w", archivePath, err))
+	}
+	size, err := strconv.ParseInt(strings.TrimSpace(stdout.String()), 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse size of archive '%s': %w", archivePath, err)
+	}
+	if size > s3.MaxObjectSize {
+		return fmt.Errorf("archive '%s' is %d bytes, which exceeds the maximum S3 object size of %d bytes", archivePath, size, int64(s3.MaxObjectSize))
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		_, err := k8s.ExecWithOptions(kube.ExecOptions{
+			Container: InfinispanContainer,
+			Command:   []string{"cat", archivePath},
+			Namespace: pod.Namespace,
+			PodName:   pod.Name,
+			Stdout:    writer,
+		})
+		_ = writer.CloseWithError(err)
+	}()
+
+	err = s3Client.PutObject(spec.Bucket, key, reader, size)
+	// Ensure that the exec is terminated if the upload failed before the archive was consumed
+	_ = reader.CloseWithError(err)
+	if err != nil {
+		return s3Error(fmt.Errorf("unable to upload archive to '%s': %w", s3Location(spec, key), err))
+	}
+	return nil
+}
+
+// downloadFromS3 streams the archive stored at the S3 location to archivePath on the zero-capacity pod
+func downloadFromS3(ctx context.Context, c client.Client, k8s *kube.Kubernetes, pod types.NamespacedName, archivePath string, spec *v2alpha1.BackupS3Spec, key string) error {
+	s3Client, err := newS3Client(ctx, c, pod.Namespace, spec)
+	if err != nil {
+		return err
+	}
+
+	body, _, err := s3Client.GetObject(spec.Bucket, key)
+	if err != nil {
+		if s3.IsNotFound(err) {
+			return fmt.Errorf("backup archive '%s' not found", s3Location(spec, key))
+		}
+		return fmt.Errorf("unable to download archive from '%s': %w", s3Location(spec, key), err)
+	}
+	defer body.Close()
+
+	_, err = k8s.ExecWithOptions(kube.ExecOptions{
+		Container: InfinispanContainer,
+		Command:   []string{"sh", "-c", fmt.Sprintf("mkdir -p %s && cat > %s", filepath.Dir(archivePath), archivePath)},
+		Namespace: pod.Namespace,
+		PodName:   pod.Name,
+		Stdin:     body,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to write archive to '%s': %w", archivePath, err)
+	}
+	return nil
+}
+
+// putS3Object uploads the content to the S3 location
+func putS3Object(ctx context.Context, c client.Client, namespace string, spec *v2alpha1.BackupS3Spec, key string, content []byte) error {
+	s3Client, err := newS3Client(ctx, c, namespace, spec)
+	if err != nil {
+		return err
+	}
+	if err := s3Client.PutObject(spec.Bucket, key, bytes.NewReader(content), int64(len(content))); err != nil {
+		return s3Error(fmt.Errorf("unable to upload '%s': %w", s3Location(spec, key), err))
+	}
+	return nil
+}
+
+// getS3Object returns the content stored at the S3 location, or nil if the object does not exist
+func getS3Object(ctx context.Context, c client.Client, namespace string, spec *v2alpha1.BackupS3Spec, key string) ([]byte, error) {
+	s3Client, err := newS3Client(ctx, c, namespace, spec)
+	if err != nil {
+		return nil, err
+	}
+	body, _, err := s3Client.GetObject(spec.Bucket, key)
+	if err != nil {
+		if s3.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to download '%s': %w", s3Location(spec, key), err)
+	}
+	defer body.Close()
+	return ioutil.ReadAll(body)
+}
+
+// deleteS3Objects removes the archive, manifest and Kubernetes resources of the Backup from the S3 location. Objects
+// that do not exist are ignored
+func deleteS3Objects(ctx context.Context, c client.Client, namespace string, spec *v2alpha1.BackupS3Spec, backup string) error {
+	s3Client, err := newS3Client(ctx, c, namespace, spec)
+	if err != nil {
+		return err
+	}
+	key := spec.ObjectKey(backup)
+	for _, k := range []string{key, manifestPath(key), resourcesPath(key)} {
+		if err := s3Client.DeleteObject(spec.Bucket, k); err != nil {
+			return fmt.Errorf("unable to delete '%s': %w", s3Location(spec, k), err)
+		}
+	}
+	return nil
+}
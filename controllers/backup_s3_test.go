@@ -0,0 +1,187 @@
+package controllers
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/pkg/s3"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// testObjectStore is a minimal in-memory S3 endpoint
+type testObjectStore struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func newTestObjectStore(keys ...string) (*testObjectStore, *httptest.Server) {
+	store := &testObjectStore{objects: map[string][]byte{}}
+	for _, key := range keys {
+		store.objects[key] = []byte("content")
+	}
+	return store, httptest.NewServer(store)
+}
+
+func (s *testObjectStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := ioutil.ReadAll(r.Body)
+		s.objects[r.URL.Path] = body
+	case http.MethodGet, http.MethodHead:
+		body, ok := s.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(body)
+	case http.MethodDelete:
+		delete(s.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *testObjectStore) keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var keys []string
+	for key := range s.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func testS3Backup(name, endpoint string, created time.Time) *v2alpha1.Backup {
+	return &v2alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, CreationTimestamp: metav1.Time{Time: created}},
+		Spec: v2alpha1.BackupSpec{
+			Cluster: "example",
+			Target: &v2alpha1.BackupTargetSpec{
+				S3: &v2alpha1.BackupS3Spec{
+					Endpoint:          endpoint,
+					Bucket:            "bucket",
+					Prefix:            "backups",
+					CredentialsSecret: "s3-credentials",
+				},
+			},
+		},
+		Status: v2alpha1.BackupStatus{Phase: v2alpha1.BackupSucceeded},
+	}
+}
+
+func testS3Credentials() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "s3-credentials", Namespace: namespace},
+		Data: map[string][]byte{
+			S3AccessKeyIdKey:     []byte("access"),
+			S3SecretAccessKeyKey: []byte("secret"),
+		},
+	}
+}
+
+func TestBackupFinalizeRetainsS3Objects(t *testing.T) {
+	store, server := newTestObjectStore("/bucket/backups/backup.zip", "/bucket/backups/backup.manifest.json")
+	defer server.Close()
+
+	// Objects are retained by default, so that they survive the deletion of the namespace or BackupSchedule
+	k8sClient, _ := testClients(t, nil, testS3Credentials())
+	for _, policy := range []v2alpha1.BackupDeletionPolicy{"", v2alpha1.BackupDeletionPolicyRetain} {
+		backup := testS3Backup("backup", server.URL, time.Now())
+		backup.Spec.Target.DeletionPolicy = policy
+		resource := &backupResource{instance: backup, client: k8sClient, eventRec: record.NewFakeRecorder(10), ctx: context.TODO()}
+		assert.NoError(t, resource.Finalize())
+		assert.Equal(t, []string{"/bucket/backups/backup.manifest.json", "/bucket/backups/backup.zip"}, store.keys())
+	}
+}
+
+func TestBackupFinalizeDeletesS3Objects(t *testing.T) {
+	store, server := newTestObjectStore(
+		"/bucket/backups/backup.zip",
+		"/bucket/backups/backup.manifest.json",
+		"/bucket/backups/backup.resources.json",
+		"/bucket/backups/other.zip",
+	)
+	defer server.Close()
+
+	backup := testS3Backup("backup", server.URL, time.Now())
+	backup.Spec.Target.DeletionPolicy = v2alpha1.BackupDeletionPolicyDelete
+	k8sClient, _ := testClients(t, nil, testS3Credentials())
+	eventRec := record.NewFakeRecorder(10)
+	resource := &backupResource{instance: backup, client: k8sClient, eventRec: eventRec, ctx: context.TODO()}
+
+	assert.NoError(t, resource.Finalize())
+	assert.Equal(t, []string{"/bucket/backups/other.zip"}, store.keys())
+
+	// The objects are retained if the credentials no longer exist, so that the Backup can still be deleted
+	backup = testS3Backup("other", server.URL, time.Now())
+	backup.Spec.Target.DeletionPolicy = v2alpha1.BackupDeletionPolicyDelete
+	k8sClient, _ = testClients(t, nil)
+	resource = &backupResource{instance: backup, client: k8sClient, eventRec: eventRec, ctx: context.TODO()}
+	assert.NoError(t, resource.Finalize())
+	assert.Equal(t, []string{"/bucket/backups/other.zip"}, store.keys())
+	assert.Contains(t, <-eventRec.Events, "S3ObjectsRetained")
+}
+
+func TestBackupScheduleRetentionDeletesS3Objects(t *testing.T) {
+	store, server := newTestObjectStore(
+		"/bucket/backups/new.zip",
+		"/bucket/backups/new.manifest.json",
+		"/bucket/backups/old.zip",
+		"/bucket/backups/old.manifest.json",
+		"/bucket/backups/old.resources.json",
+	)
+	defer server.Close()
+
+	now := time.Now()
+	newBackup := testS3Backup("new", server.URL, now)
+	oldBackup := testS3Backup("old", server.URL, now.Add(-time.Hour))
+	schedule := &v2alpha1.BackupSchedule{
+		ObjectMeta: testObjectMeta("schedule"),
+		Spec: v2alpha1.BackupScheduleSpec{
+			Retention: &v2alpha1.BackupRetentionSpec{Last: 1},
+		},
+	}
+	k8sClient, _ := testClients(t, nil, testS3Credentials(), schedule, newBackup, oldBackup)
+	request := &backupScheduleRequest{
+		BackupScheduleReconciler: &BackupScheduleReconciler{
+			Client:   k8sClient,
+			eventRec: record.NewFakeRecorder(10),
+		},
+		ctx:       context.TODO(),
+		schedule:  schedule,
+		reqLogger: ctrl.Log,
+	}
+
+	assert.NoError(t, request.applyRetention([]v2alpha1.Backup{*newBackup, *oldBackup}))
+	assert.Equal(t, []string{"/bucket/backups/new.manifest.json", "/bucket/backups/new.zip"}, store.keys())
+	err := k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: "old"}, &v2alpha1.Backup{})
+	assert.True(t, errors.IsNotFound(err))
+	assert.NoError(t, k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(newBackup), &v2alpha1.Backup{}))
+}
+
+func TestS3ErrorTransient(t *testing.T) {
+	unavailable := fmt.Errorf("unable to upload: %w", &s3.Error{StatusCode: http.StatusServiceUnavailable})
+	assert.True(t, isTransient(s3Error(unavailable)))
+	assert.True(t, stderrors.Is(s3Error(unavailable), unavailable))
+	assert.True(t, isTransient(s3Error(fmt.Errorf("connection refused"))))
+	assert.False(t, isTransient(s3Error(fmt.Errorf("unable to upload: %w", &s3.Error{StatusCode: http.StatusForbidden}))))
+	assert.Nil(t, transient(nil))
+}
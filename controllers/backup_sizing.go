@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// The percentage of the estimated backup size added to the PVC if BackupVolumeSpec.HeadroomPercent is not defined
+const DefaultBackupHeadroomPercent = 50
+
+var metricHeapUsed = []metricQuery{
+	{name: "base_memory_usedHeap_bytes"},
+}
+
+// backupSizeInputs contains the cluster statistics that a backup size estimate is based upon
+type backupSizeInputs struct {
+	// The statistics of each cache in the cluster
+	cacheStats map[string]*api.CacheStats
+	// The data memory used by each pod
+	podDataMemory []float64
+	// The heap used by each pod
+	podHeapUsed []float64
+}
+
+// estimateBackupSize returns the number of bytes required to store a backup of the requested resources. The estimate is
+// the larger of the memory used by the requested caches and, if all caches are included, the data memory used across
+// all pods. As data is typically replicated on multiple pods, the latter overestimates the size of the archive.
+//
+// The memory used by a cache is only reported when memory based eviction is configured. The size of other caches is
+// estimated from their number of entries and the average entry size of the caches that do report their memory usage or,
+// if no cache reports its memory usage, from the proportion of all entries that they contain multiplied by the heap used
+// across all pods.
+func estimateBackupSize(inputs *backupSizeInputs, resources *v2alpha1.BackupResources) int64 {
+	var caches []string
+	if resources != nil {
+		caches = resources.Caches
+	}
+	allCaches := len(caches) == 0 || contains(caches, manifestWildcard)
+
+	var cacheTotal, unknownEntries, allEntries, measuredMemory, measuredEntries int64
+	for name, stats := range inputs.cacheStats {
+		used := stats.DataMemoryUsed + stats.OffHeapMemoryUsed
+		if used > 0 {
+			measuredMemory += used
+			measuredEntries += stats.Entries
+		}
+		allEntries += stats.Entries
+		if allCaches || contains(caches, name) {
+			if used > 0 {
+				cacheTotal += used
+			} else {
+				unknownEntries += stats.Entries
+			}
+		}
+	}
+
+	if unknownEntries > 0 {
+		if measuredEntries > 0 {
+			cacheTotal += unknownEntries * measuredMemory / measuredEntries
+		} else {
+			var heapTotal float64
+			for _, used := range inputs.podHeapUsed {
+				heapTotal += used
+			}
+			// The heap also contains the server's own objects, so this overestimates the size of the archive
+			cacheTotal += int64(heapTotal * float64(unknownEntries) / float64(allEntries))
+		}
+	}
+
+	if !allCaches {
+		return cacheTotal
+	}
+
+	var podTotal int64
+	for _, used := range inputs.podDataMemory {
+		podTotal += int64(used)
+	}
+	if podTotal > cacheTotal {
+		return podTotal
+	}
+	return cacheTotal
+}
+
+// backupPvcSize adds the headroom to the estimated size, rounding up to the nearest MiB. The returned size is never
+// smaller than constants.DefaultPVSize.
+func backupPvcSize(estimate int64, headroomPercent int32) resource.Quantity {
+	const mebibyte = 1024 * 1024
+	size := estimate + estimate*int64(headroomPercent)/100
+	size = (size + mebibyte - 1) / mebibyte * mebibyte
+	if size < constants.DefaultPVSize.Value() {
+		return constants.DefaultPVSize.DeepCopy()
+	}
+	return *resource.NewQuantity(size, resource.BinarySI)
+}
+
+// collectBackupSizeInputs retrieves the cache statistics from the cluster and the data memory and heap metrics from each
+// pod
+func collectBackupSizeInputs(ctx context.Context, infinispan *v1.Infinispan, k8s *kube.Kubernetes, clients *InfinispanClientFactory) (*backupSizeInputs, error) {
+	ispnClient, err := clients.NewInfinispan(ctx, infinispan)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create Infinispan client: %w", err)
+	}
+
+	names, err := ispnClient.Caches().Names()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve cache names: %w", err)
+	}
+
+	inputs := &backupSizeInputs{cacheStats: make(map[string]*api.CacheStats, len(names))}
+	for _, name := range names {
+		stats, err := ispnClient.Cache(name).Stats()
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve statistics of cache '%s': %w", name, err)
+		}
+		inputs.cacheStats[name] = stats
+	}
+
+	podList := &corev1.PodList{}
+	if err := k8s.ResourcesList(infinispan.Namespace, PodLabels(infinispan.Name), podList, ctx); err != nil {
+		return nil, fmt.Errorf("unable to list pods: %w", err)
+	}
+	for _, pod := range podList.Items {
+		if !kube.IsPodReady(pod) {
+			continue
+		}
+		podClient, err := clients.NewInfinispanForPod(ctx, pod.Name, infinispan)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create Infinispan client for pod '%s': %w", pod.Name, err)
+		}
+		samples, err := podClient.Metrics().Get()
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve metrics of pod '%s': %w", pod.Name, err)
+		}
+		// Data memory is only tracked when memory based eviction is configured, so the metric may not be present
+		if used, err := getMetricValue(samples, metricDataMemoryUsed); err == nil {
+			inputs.podDataMemory = append(inputs.podDataMemory, used)
+		}
+		if used, err := getMetricValue(samples, metricHeapUsed); err == nil {
+			inputs.podHeapUsed = append(inputs.podHeapUsed, used)
+		}
+	}
+	return inputs, nil
+}
@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateBackupSize(t *testing.T) {
+	inputs := &backupSizeInputs{
+		cacheStats: map[string]*api.CacheStats{
+			"cache1": {DataMemoryUsed: 100},
+			"cache2": {DataMemoryUsed: 200, OffHeapMemoryUsed: 50},
+		},
+		podDataMemory: []float64{150, 150},
+	}
+	// The cache statistics exceed the data memory reported by the pods
+	assert.Equal(t, int64(350), estimateBackupSize(inputs, nil))
+	assert.Equal(t, int64(100), estimateBackupSize(inputs, &v2alpha1.BackupResources{Caches: []string{"cache1"}}))
+	assert.Equal(t, int64(350), estimateBackupSize(inputs, &v2alpha1.BackupResources{Caches: []string{"*"}}))
+
+	inputs.podDataMemory = []float64{300, 300}
+	assert.Equal(t, int64(600), estimateBackupSize(inputs, &v2alpha1.BackupResources{Counters: []string{"counter"}}))
+	// Pod metrics are ignored when only a subset of the caches is included
+	assert.Equal(t, int64(250), estimateBackupSize(inputs, &v2alpha1.BackupResources{Caches: []string{"cache2"}}))
+}
+
+func TestEstimateBackupSizeWithoutMemoryStats(t *testing.T) {
+	// The size of caches without memory statistics is estimated from the average entry size of the other caches
+	inputs := &backupSizeInputs{
+		cacheStats: map[string]*api.CacheStats{
+			"cache1": {Entries: 10, DataMemoryUsed: 1000},
+			"cache2": {Entries: 20},
+		},
+		podHeapUsed: []float64{100000, 100000},
+	}
+	assert.Equal(t, int64(3000), estimateBackupSize(inputs, nil))
+	assert.Equal(t, int64(2000), estimateBackupSize(inputs, &v2alpha1.BackupResources{Caches: []string{"cache2"}}))
+
+	// The heap is shared proportionally between the caches if no cache reports its memory usage
+	inputs.cacheStats["cache1"].DataMemoryUsed = 0
+	inputs.cacheStats["cache2"].Entries = 30
+	assert.Equal(t, int64(200000), estimateBackupSize(inputs, nil))
+	assert.Equal(t, int64(150000), estimateBackupSize(inputs, &v2alpha1.BackupResources{Caches: []string{"cache2"}}))
+
+	// Empty caches do not require any storage
+	inputs.cacheStats = map[string]*api.CacheStats{"cache1": {}}
+	assert.Equal(t, int64(0), estimateBackupSize(inputs, nil))
+}
+
+func TestBackupPvcSize(t *testing.T) {
+	gi := int64(1024 * 1024 * 1024)
+	size := func(estimate int64, headroom int32) string {
+		q := backupPvcSize(estimate, headroom)
+		return q.String()
+	}
+	assert.Equal(t, constants.DefaultPVSize.String(), size(0, 50))
+	assert.Equal(t, constants.DefaultPVSize.String(), size(100, 50))
+	assert.Equal(t, "3Gi", size(2*gi, 50))
+	assert.Equal(t, "2Gi", size(2*gi, 0))
+	// Sizes are rounded up to the nearest MiB
+	assert.Equal(t, "2049Mi", size(2*gi+1, 0))
+}
@@ -0,0 +1,319 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	v2alpha1 "github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/controllers/constants"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// BackupScheduleReconciler reconciles a BackupSchedule object
+type BackupScheduleReconciler struct {
+	client.Client
+	log        logr.Logger
+	scheme     *runtime.Scheme
+	kubernetes *kube.Kubernetes
+	eventRec   record.EventRecorder
+}
+
+type backupScheduleRequest struct {
+	*BackupScheduleReconciler
+	ctx       context.Context
+	schedule  *v2alpha1.BackupSchedule
+	reqLogger logr.Logger
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BackupScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Client = mgr.GetClient()
+	r.log = ctrl.Log.WithName("controllers").WithName("BackupSchedule")
+	r.scheme = mgr.GetScheme()
+	r.kubernetes = kube.NewKubernetesFromController(mgr)
+	r.eventRec = mgr.GetEventRecorderFor("backupschedule-controller")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v2alpha1.BackupSchedule{}).
+		Owns(&v2alpha1.Backup{}).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=infinispan.org,namespace=infinispan-operator-system,resources=backupschedules;backupschedules/status;backupschedules/finalizers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=infinispan.org,namespace=infinispan-operator-system,resources=backups,verbs=delete
+// +kubebuilder:rbac:groups=core,namespace=infinispan-operator-system,resources=persistentvolumeclaims,verbs=delete
+
+func (r *BackupScheduleReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	reqLogger := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("+++++ Reconciling BackupSchedule.")
+	defer reqLogger.Info("----- End Reconciling BackupSchedule.")
+
+	// Fetch the BackupSchedule instance
+	instance := &v2alpha1.BackupSchedule{}
+	if err := r.Client.Get(ctx, request.NamespacedName, instance); err != nil {
+		if errors.IsNotFound(err) {
+			reqLogger.Info("BackupSchedule resource not found. Ignoring it since the object must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Backups are owned by the BackupSchedule, so they are garbage collected once the schedule is removed
+	if instance.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	schedule := &backupScheduleRequest{
+		BackupScheduleReconciler: r,
+		ctx:                      ctx,
+		schedule:                 instance,
+		reqLogger:                reqLogger,
+	}
+
+	backups, err := schedule.backups()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := schedule.applyRetention(backups); err != nil {
+		return ctrl.Result{}, err
+	}
+	return schedule.execute(backups)
+}
+
+func (r *backupScheduleRequest) update(mutate func() error) error {
+	backupSchedule := r.schedule
+	_, err := kube.CreateOrPatch(r.ctx, r.Client, backupSchedule, func() error {
+		if backupSchedule.CreationTimestamp.IsZero() {
+			return errors.NewNotFound(schema.ParseGroupResource("backupschedule.infinispan.org"), backupSchedule.Name)
+		}
+		return mutate()
+	})
+	if err != nil {
+		return fmt.Errorf("unable to update backupschedule %s: %w", backupSchedule.Name, err)
+	}
+	return nil
+}
+
+// backups returns all Backups created by the schedule, ordered from the most to the least recent
+func (r *backupScheduleRequest) backups() ([]v2alpha1.Backup, error) {
+	backupList := &v2alpha1.BackupList{}
+	labels := map[string]string{constants.BackupScheduleLabel: r.schedule.Name}
+	if err := r.kubernetes.ResourcesList(r.schedule.Namespace, labels, backupList, r.ctx); err != nil {
+		return nil, fmt.Errorf("unable to list Backups: %w", err)
+	}
+
+	var backups []v2alpha1.Backup
+	for _, backup := range backupList.Items {
+		if metav1.IsControlledBy(&backup, r.schedule) {
+			backups = append(backups, backup)
+		}
+	}
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[j].CreationTimestamp.Before(&backups[i].CreationTimestamp)
+	})
+	return backups, nil
+}
+
+// applyRetention removes the Backups, and their PersistentVolumeClaims or S3 objects, that are no longer retained by the
+// schedule's retention policy and records the time of the most recent successful Backup
+func (r *backupScheduleRequest) applyRetention(backups []v2alpha1.Backup) error {
+	backupSchedule := r.schedule
+	var lastSuccessful *metav1.Time
+	for _, backup := range backups {
+		if backup.Status.Phase == v2alpha1.BackupSucceeded {
+			lastSuccessful = backup.CreationTimestamp.DeepCopy()
+			break
+		}
+	}
+
+	for _, backup := range expiredBackups(backups, backupSchedule.Spec.Retention) {
+		r.reqLogger.Info("Removing expired Backup", "Backup", backup.Name)
+		// The objects are removed before the Backup, so that they're not orphaned if the removal fails
+		if s3Spec := backup.S3(); s3Spec != nil {
+			if err := deleteS3Objects(r.ctx, r.Client, backup.Namespace, s3Spec, backup.Name); err != nil {
+				if !errors.IsNotFound(err) {
+					return err
+				}
+				r.eventRec.Event(backupSchedule, corev1.EventTypeWarning, "S3ObjectsRetained", fmt.Sprintf("Unable to remove '%s': %s", s3Location(s3Spec, s3Spec.ObjectKey(backup.Name)), err))
+			}
+		}
+		if err := r.Client.Delete(r.ctx, &backup); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("unable to delete Backup '%s': %w", backup.Name, err)
+		}
+		// The PVC is owned by the Backup, however we remove it explicitly so that storage is reclaimed immediately
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      backup.Name,
+				Namespace: backup.Namespace,
+			},
+		}
+		if err := r.Client.Delete(r.ctx, pvc); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("unable to delete pvc '%s': %w", pvc.Name, err)
+		}
+		r.eventRec.Event(backupSchedule, corev1.EventTypeNormal, "BackupExpired", fmt.Sprintf("Backup '%s' removed by retention policy", backup.Name))
+	}
+
+	if lastSuccessful == nil || lastSuccessful.Equal(backupSchedule.Status.LastSuccessfulTime) {
+		return nil
+	}
+	return r.update(func() error {
+		backupSchedule.Status.LastSuccessfulTime = lastSuccessful
+		return nil
+	})
+}
+
+// expiredBackups returns the Backups that are not retained by the retention policy. The provided backups must be
+// ordered from the most to the least recent. Backups that have not completed are always retained, as are Failed
+// Backups that are more recent than the last successful Backup.
+func expiredBackups(backups []v2alpha1.Backup, retention *v2alpha1.BackupRetentionSpec) []v2alpha1.Backup {
+	if retention == nil {
+		return nil
+	}
+
+	var expired []v2alpha1.Backup
+	var succeeded int32
+	days := map[string]bool{}
+	weeks := map[string]bool{}
+	for _, backup := range backups {
+		switch backup.Status.Phase {
+		case v2alpha1.BackupSucceeded:
+			retain := false
+			succeeded++
+			if succeeded <= retention.Last {
+				retain = true
+			}
+
+			created := backup.CreationTimestamp.UTC()
+			day := created.Format("2006-01-02")
+			if !days[day] && int32(len(days)) < retention.Daily {
+				days[day] = true
+				retain = true
+			}
+
+			year, week := created.ISOWeek()
+			isoWeek := fmt.Sprintf("%d-%d", year, week)
+			if !weeks[isoWeek] && int32(len(weeks)) < retention.Weekly {
+				weeks[isoWeek] = true
+				retain = true
+			}
+
+			if !retain {
+				expired = append(expired, backup)
+			}
+		case v2alpha1.BackupFailed:
+			if succeeded > 0 {
+				expired = append(expired, backup)
+			}
+		}
+	}
+	return expired
+}
+
+// execute creates a Backup if the next scheduled run is due. The returned Result ensures that the request is requeued
+// in time for the next scheduled run.
+func (r *backupScheduleRequest) execute(backups []v2alpha1.Backup) (ctrl.Result, error) {
+	backupSchedule := r.schedule
+	cronSchedule, err := cron.ParseStandard(backupSchedule.Spec.Schedule)
+	if err != nil {
+		return ctrl.Result{}, r.update(func() error {
+			backupSchedule.Status.NextScheduleTime = nil
+			backupSchedule.SetCondition(v2alpha1.BackupScheduleConditionReady, metav1.ConditionFalse, fmt.Sprintf("invalid schedule '%s': %v", backupSchedule.Spec.Schedule, err))
+			return nil
+		})
+	}
+
+	// Don't create Backups that are certain to fail
+	if err := validateBackupSpec(&backupSchedule.Spec.Template); err != nil {
+		return ctrl.Result{}, r.update(func() error {
+			backupSchedule.Status.NextScheduleTime = nil
+			backupSchedule.SetCondition(v2alpha1.BackupScheduleConditionReady, metav1.ConditionFalse, fmt.Sprintf("invalid template: %v", err))
+			return nil
+		})
+	}
+
+	now := time.Now()
+	last := backupSchedule.CreationTimestamp.Time
+	if backupSchedule.Status.LastScheduleTime != nil {
+		last = backupSchedule.Status.LastScheduleTime.Time
+	}
+
+	next := cronSchedule.Next(last)
+	if !next.After(now) {
+		next = cronSchedule.Next(now)
+		if err := r.run(now, next, backups); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else if err := r.update(func() error {
+		backupSchedule.Status.NextScheduleTime = &metav1.Time{Time: next}
+		backupSchedule.SetCondition(v2alpha1.BackupScheduleConditionReady, metav1.ConditionTrue, "")
+		return nil
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: time.Until(next)}, nil
+}
+
+// run creates a new Backup from the schedule's template, unless the schedule is suspended or a previously created
+// Backup has not yet completed
+func (r *backupScheduleRequest) run(now, next time.Time, backups []v2alpha1.Backup) error {
+	backupSchedule := r.schedule
+	var backupName string
+	if backupSchedule.Spec.Suspend {
+		r.reqLogger.Info("BackupSchedule suspended, skipping scheduled Backup")
+	} else if inProgress := inProgressBackup(backups); inProgress != nil {
+		r.reqLogger.Info("Previous Backup not completed, skipping scheduled Backup", "Backup", inProgress.Name)
+		r.eventRec.Event(backupSchedule, corev1.EventTypeWarning, "BackupSkipped", fmt.Sprintf("Scheduled Backup skipped as Backup '%s' is still in progress", inProgress.Name))
+	} else {
+		backup := &v2alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%d", backupSchedule.Name, now.Unix()),
+				Namespace: backupSchedule.Namespace,
+				Labels:    map[string]string{constants.BackupScheduleLabel: backupSchedule.Name},
+			},
+			Spec: *backupSchedule.Spec.Template.DeepCopy(),
+		}
+		if err := controllerutil.SetControllerReference(backupSchedule, backup, r.scheme); err != nil {
+			return err
+		}
+		if err := r.Client.Create(r.ctx, backup); err != nil && !errors.IsAlreadyExists(err) {
+			r.eventRec.Event(backupSchedule, corev1.EventTypeWarning, "BackupFailed", fmt.Sprintf("Unable to create Backup '%s': %v", backup.Name, err))
+			return fmt.Errorf("unable to create Backup '%s': %w", backup.Name, err)
+		}
+		r.eventRec.Event(backupSchedule, corev1.EventTypeNormal, "BackupCreated", fmt.Sprintf("Created Backup '%s'", backup.Name))
+		backupName = backup.Name
+	}
+
+	return r.update(func() error {
+		backupSchedule.Status.LastScheduleTime = &metav1.Time{Time: now}
+		backupSchedule.Status.NextScheduleTime = &metav1.Time{Time: next}
+		if backupName != "" {
+			backupSchedule.Status.LastBackup = backupName
+		}
+		backupSchedule.SetCondition(v2alpha1.BackupScheduleConditionReady, metav1.ConditionTrue, "")
+		return nil
+	})
+}
+
+// inProgressBackup returns the first Backup that has not yet completed, or nil if all Backups have completed
+func inProgressBackup(backups []v2alpha1.Backup) *v2alpha1.Backup {
+	for i := range backups {
+		if !backups[i].IsCompleted() {
+			return &backups[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	v2alpha1 "github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// scheduledBackups returns a Backup for each phase, created at 12 hour intervals going back from the start time
+func scheduledBackups(start time.Time, phases ...v2alpha1.BackupPhase) []v2alpha1.Backup {
+	backups := make([]v2alpha1.Backup, len(phases))
+	for i, phase := range phases {
+		created := start.Add(-time.Duration(i) * 12 * time.Hour)
+		backups[i] = v2alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              fmt.Sprintf("backup-%d", i),
+				CreationTimestamp: metav1.Time{Time: created},
+			},
+			Status: v2alpha1.BackupStatus{Phase: phase},
+		}
+	}
+	return backups
+}
+
+func expiredNames(backups []v2alpha1.Backup) []string {
+	var names []string
+	for _, b := range backups {
+		names = append(names, b.Name)
+	}
+	return names
+}
+
+func TestExpiredBackups(t *testing.T) {
+	// Monday 12:00 UTC
+	start := time.Date(2022, time.March, 14, 12, 0, 0, 0, time.UTC)
+	s := v2alpha1.BackupSucceeded
+	f := v2alpha1.BackupFailed
+	r := v2alpha1.BackupRunning
+
+	backups := scheduledBackups(start, s, s, s, s, s, s)
+	assert.Nil(t, expiredBackups(backups, nil))
+	assert.Equal(t, []string{"backup-3", "backup-4", "backup-5"}, expiredNames(expiredBackups(backups, &v2alpha1.BackupRetentionSpec{Last: 3})))
+	// backup-0, backup-2 and backup-4 are the most recent of Monday, Sunday and Saturday respectively
+	assert.Equal(t, []string{"backup-1", "backup-3", "backup-5"}, expiredNames(expiredBackups(backups, &v2alpha1.BackupRetentionSpec{Daily: 3})))
+	// backup-0 is the most recent of the current week and backup-2 of the previous week
+	assert.Equal(t, []string{"backup-1", "backup-3", "backup-4", "backup-5"}, expiredNames(expiredBackups(backups, &v2alpha1.BackupRetentionSpec{Weekly: 2})))
+	assert.Equal(t, []string{"backup-1", "backup-3", "backup-4", "backup-5"}, expiredNames(expiredBackups(backups, &v2alpha1.BackupRetentionSpec{Last: 1, Daily: 2, Weekly: 2})))
+
+	// In progress Backups and Failed Backups more recent than the last successful Backup are retained
+	backups = scheduledBackups(start, r, f, s, f, s)
+	assert.Equal(t, []string{"backup-3", "backup-4"}, expiredNames(expiredBackups(backups, &v2alpha1.BackupRetentionSpec{Last: 1})))
+}
+
+func TestBackupScheduleInvalidTemplate(t *testing.T) {
+	schedule := &v2alpha1.BackupSchedule{
+		ObjectMeta: testObjectMeta("schedule"),
+		Spec: v2alpha1.BackupScheduleSpec{
+			Schedule: "0 * * * *",
+			Template: v2alpha1.BackupSpec{Cluster: "example", IncludeKubernetesResources: true},
+		},
+	}
+	k8sClient, _ := testClients(t, nil, schedule)
+	request := &backupScheduleRequest{
+		BackupScheduleReconciler: &BackupScheduleReconciler{Client: k8sClient},
+		ctx:                      context.TODO(),
+		schedule:                 schedule,
+		reqLogger:                ctrl.Log,
+	}
+
+	result, err := request.execute(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, result)
+	assert.Nil(t, schedule.Status.NextScheduleTime)
+	assert.Len(t, schedule.Status.Conditions, 1)
+	condition := schedule.Status.Conditions[0]
+	assert.Equal(t, v2alpha1.BackupScheduleConditionReady, condition.Type)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Contains(t, condition.Message, "'spec.encryption' must be defined")
+}
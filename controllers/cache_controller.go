@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/iancoleman/strcase"
@@ -36,6 +37,7 @@ type CacheReconciler struct {
 	log        logr.Logger
 	scheme     *runtime.Scheme
 	kubernetes *kube.Kubernetes
+	clients    *InfinispanClientFactory
 	eventRec   record.EventRecorder
 }
 
@@ -62,6 +64,7 @@ func (r *CacheReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager
 	r.log = ctrl.Log.WithName("controllers").WithName("Cache")
 	r.scheme = mgr.GetScheme()
 	r.kubernetes = kube.NewKubernetesFromController(mgr)
+	r.clients = NewInfinispanClientFactory(r.kubernetes)
 	r.eventRec = mgr.GetEventRecorderFor("cache-controller")
 
 	if err := mgr.GetFieldIndexer().IndexField(ctx, &v2alpha1.Cache{}, "spec.clusterName", func(obj client.Object) []string {
@@ -171,7 +174,7 @@ func (r *CacheReconciler) Reconcile(ctx context.Context, request ctrl.Request) (
 		return ctrl.Result{}, nil
 	}
 
-	ispnClient, err := NewInfinispan(ctx, infinispan, r.kubernetes)
+	ispnClient, err := r.clients.NewInfinispan(ctx, infinispan)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("unable to create Infinispan client: %w", err)
 	}
@@ -210,7 +213,10 @@ func (r *CacheReconciler) Reconcile(ctx context.Context, request ctrl.Request) (
 		}
 		return nil
 	})
-	return ctrl.Result{}, err
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	return cache.reconcileStats()
 }
 
 func (r *cacheRequest) update(mutate func() error) error {
@@ -270,6 +276,45 @@ func (r *cacheRequest) ispnCreateOrUpdate() (*ctrl.Result, error) {
 	return nil, nil
 }
 
+// reconcileStats publishes a summary of the cache statistics to the Cache status, refreshing it at most once every
+// DefaultCacheStatsRefreshPeriod so that status updates don't continuously trigger reconciliation
+func (r *cacheRequest) reconcileStats() (ctrl.Result, error) {
+	period := constants.DefaultCacheStatsRefreshPeriod
+	if stats := r.cache.Status.Stats; stats != nil {
+		if elapsed := time.Since(stats.LastUpdated.Time); elapsed < period {
+			return ctrl.Result{RequeueAfter: period - elapsed}, nil
+		}
+	}
+
+	stats, err := r.ispnClient.Cache(r.cache.GetCacheName()).Stats()
+	if err != nil {
+		// The statistics are informational, so a failure to retrieve them should not fail the reconciliation
+		r.reqLogger.Error(err, "Unable to retrieve cache statistics")
+		return ctrl.Result{RequeueAfter: period}, nil
+	}
+
+	memoryUsed := stats.DataMemoryUsed
+	if stats.OffHeapMemoryUsed > 0 {
+		memoryUsed += stats.OffHeapMemoryUsed
+	}
+	err = r.update(func() error {
+		r.cache.Status.Stats = &v2alpha1.CacheStatsSummary{
+			Entries:            stats.Entries,
+			Hits:               stats.Hits,
+			Misses:             stats.Misses,
+			Evictions:          stats.Evictions,
+			MemoryUsed:         memoryUsed,
+			AverageReadTime:    stats.AverageReadTimeNanos,
+			AverageWriteTime:   stats.AverageWriteTimeNanos,
+			Rebalancing:        stats.RehashInProgress,
+			RebalancingEnabled: stats.RebalancingEnabled,
+			LastUpdated:        metav1.Now(),
+		}
+		return nil
+	})
+	return ctrl.Result{RequeueAfter: period}, err
+}
+
 func (r *cacheRequest) reconcileCacheService(cacheExists bool, cache api.Cache) error {
 	spec := r.cache.Spec
 	if cacheExists {
@@ -374,7 +419,7 @@ func (cl *CacheListener) CreateOrUpdate(data []byte) error {
 				if mediaType == mime.ApplicationYaml {
 					template = configYaml
 				} else {
-					ispnClient, err := NewInfinispan(cl.Ctx, cl.Infinispan, cl.Kubernetes)
+					ispnClient, err := NewInfinispanClientFactory(cl.Kubernetes).NewInfinispan(cl.Ctx, cl.Infinispan)
 					if err != nil {
 						return fmt.Errorf("unable to create Infinispan client: %w", err)
 					}
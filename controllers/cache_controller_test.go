@@ -0,0 +1,152 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	v1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/http"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/fake"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	k8sfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// testClients returns a fake Kubernetes client containing objs and an InfinispanClientFactory whose clients are all
+// connected to server
+func testClients(t *testing.T, server *fake.Server, objs ...client.Object) (client.Client, *InfinispanClientFactory) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, v1.AddToScheme(scheme))
+	assert.NoError(t, v2alpha1.AddToScheme(scheme))
+	k8sClient := k8sfake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	kubernetes := &kube.Kubernetes{Client: k8sClient}
+	return k8sClient, NewInfinispanClientFactoryForHttpClient(kubernetes, func(string, *v1.Infinispan) (http.HttpClient, error) {
+		return server.Client(), nil
+	})
+}
+
+// testInfinispan returns a well formed DataGrid cluster
+func testInfinispan() *v1.Infinispan {
+	image := "quay.io/infinispan/server:13.0"
+	ispn := &v1.Infinispan{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: namespace},
+		Spec: v1.InfinispanSpec{
+			Image:   &image,
+			Service: v1.InfinispanServiceSpec{Type: v1.ServiceTypeDataGrid},
+		},
+	}
+	ispn.SetCondition(v1.ConditionGracefulShutdown, metav1.ConditionFalse, "")
+	ispn.SetCondition(v1.ConditionPrelimChecksPassed, metav1.ConditionTrue, "")
+	ispn.SetCondition(v1.ConditionUpgrade, metav1.ConditionFalse, "")
+	ispn.SetCondition(v1.ConditionStopping, metav1.ConditionFalse, "")
+	ispn.SetCondition(v1.ConditionWellFormed, metav1.ConditionTrue, "")
+	return ispn
+}
+
+// testObjectMeta returns the metadata of a CR in the test namespace. The fake client does not set the CreationTimestamp,
+// which is used to determine if the CR exists
+func testObjectMeta(name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Name: name, Namespace: namespace, CreationTimestamp: metav1.Now()}
+}
+
+// testRequest returns the reconcile request for the CR
+func testRequest(obj client.Object) ctrl.Request {
+	return ctrl.Request{NamespacedName: client.ObjectKeyFromObject(obj)}
+}
+
+// testReconciler has the same fields as the Cache, Counter, Schema and Task reconcilers, so that it can be converted to
+// any of them
+type testReconciler struct {
+	client.Client
+	log        logr.Logger
+	scheme     *runtime.Scheme
+	kubernetes *kube.Kubernetes
+	clients    *InfinispanClientFactory
+	eventRec   record.EventRecorder
+}
+
+func newTestReconciler(k8sClient client.Client, clients *InfinispanClientFactory) testReconciler {
+	return testReconciler{
+		Client:     k8sClient,
+		log:        logger,
+		scheme:     k8sClient.Scheme(),
+		kubernetes: &kube.Kubernetes{Client: k8sClient},
+		clients:    clients,
+		eventRec:   record.NewFakeRecorder(10),
+	}
+}
+
+func TestCacheReconcilerCreatesCache(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	cache := &v2alpha1.Cache{
+		ObjectMeta: testObjectMeta("example-cache"),
+		Spec: v2alpha1.CacheSpec{
+			ClusterName: "example",
+			Name:        "cache",
+			Template:    `{"distributed-cache":{"mode":"SYNC"}}`,
+		},
+	}
+	k8sClient, clients := testClients(t, server, testInfinispan(), cache)
+	r := CacheReconciler(newTestReconciler(k8sClient, clients))
+
+	request := testRequest(cache)
+	result, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.DefaultCacheStatsRefreshPeriod, result.RequeueAfter)
+
+	server.Update(func(s *fake.State) {
+		assert.Contains(t, s.Caches, "cache")
+		assert.Equal(t, cache.Spec.Template, s.Caches["cache"].Config)
+	})
+
+	updated := &v2alpha1.Cache{}
+	assert.NoError(t, k8sClient.Get(context.TODO(), request.NamespacedName, updated))
+	assert.Contains(t, updated.Finalizers, constants.InfinispanFinalizer)
+	assert.Len(t, updated.Status.Conditions, 1)
+	assert.Equal(t, v2alpha1.CacheConditionReady, updated.Status.Conditions[0].Type)
+	assert.Equal(t, metav1.ConditionTrue, updated.Status.Conditions[0].Status)
+	assert.NotNil(t, updated.Status.Stats)
+}
+
+func TestCacheReconcilerDeletesCache(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	server.AddCache("cache", `{"local-cache":{}}`)
+
+	now := metav1.Now()
+	cache := &v2alpha1.Cache{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "example-cache",
+			Namespace:         namespace,
+			CreationTimestamp: now,
+			DeletionTimestamp: &now,
+			Finalizers:        []string{constants.InfinispanFinalizer},
+		},
+		Spec: v2alpha1.CacheSpec{ClusterName: "example", Name: "cache"},
+	}
+	k8sClient, clients := testClients(t, server, testInfinispan(), cache)
+	r := CacheReconciler(newTestReconciler(k8sClient, clients))
+
+	request := testRequest(cache)
+	_, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+
+	server.Update(func(s *fake.State) {
+		assert.NotContains(t, s.Caches, "cache")
+	})
+	updated := &v2alpha1.Cache{}
+	assert.NoError(t, k8sClient.Get(context.TODO(), request.NamespacedName, updated))
+	assert.NotContains(t, updated.Finalizers, constants.InfinispanFinalizer)
+}
@@ -3,32 +3,136 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	v1 "github.com/infinispan/infinispan-operator/api/v1"
 	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/http"
 	"github.com/infinispan/infinispan-operator/pkg/http/curl"
+	"github.com/infinispan/infinispan-operator/pkg/http/native"
 	"github.com/infinispan/infinispan-operator/pkg/infinispan/client"
 	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
 	users "github.com/infinispan/infinispan-operator/pkg/infinispan/security"
 	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
 )
 
+// HttpClientType determines the http.HttpClient implementation used to communicate with Infinispan clusters
+type HttpClientType string
+
+const (
+	// HttpClientCurl executes curl commands inside the server pods
+	HttpClientCurl HttpClientType = "curl"
+	// HttpClientNative connects directly to the admin endpoint of the server pods
+	HttpClientNative HttpClientType = "native"
+)
+
+// InfinispanHttpClient is the http.HttpClient implementation used by the clients created by an InfinispanClientFactory
+var InfinispanHttpClient = HttpClientType(strings.ToLower(consts.GetEnvWithDefault("INFINISPAN_HTTP_CLIENT", string(HttpClientCurl))))
+
+var clientLog = ctrl.Log.WithName("infinispan-client")
+
+// HttpClientFactory creates the http.HttpClient used to communicate with the provided host, which is either a pod name or
+// the cluster's admin service
+type HttpClientFactory func(host string, i *v1.Infinispan) (http.HttpClient, error)
+
+// InfinispanClientFactory creates the api.Infinispan clients used by the reconcilers to communicate with Infinispan clusters.
+// Reconcilers obtain all clients via their factory, so that they can be unit tested against an in-process server, such as
+// fake.Server, by providing a factory created with NewInfinispanClientFactoryForHttpClient
+type InfinispanClientFactory struct {
+	kubernetes *kube.Kubernetes
+	httpClient HttpClientFactory
+}
+
+// NewInfinispanClientFactory returns an InfinispanClientFactory that creates clients using the configured InfinispanHttpClient
+func NewInfinispanClientFactory(kubernetes *kube.Kubernetes) *InfinispanClientFactory {
+	return &InfinispanClientFactory{kubernetes: kubernetes}
+}
+
+// NewInfinispanClientFactoryForHttpClient returns an InfinispanClientFactory that creates clients using the http.HttpClient
+// created by httpClient, in place of the configured InfinispanHttpClient
+func NewInfinispanClientFactoryForHttpClient(kubernetes *kube.Kubernetes, httpClient HttpClientFactory) *InfinispanClientFactory {
+	return &InfinispanClientFactory{kubernetes: kubernetes, httpClient: httpClient}
+}
+
 // NewInfinispan returns a new api.Infinispan client using the first pod in the cluster's StatefulSet
-func NewInfinispan(ctx context.Context, i *v1.Infinispan, kubernetes *kube.Kubernetes) (api.Infinispan, error) {
-	podList, err := PodsCreatedBy(i.Namespace, kubernetes, ctx, i.GetStatefulSetName())
+func (f *InfinispanClientFactory) NewInfinispan(ctx context.Context, i *v1.Infinispan) (api.Infinispan, error) {
+	if InfinispanHttpClient == HttpClientNative || f.httpClient != nil {
+		return f.NewStreamingInfinispan(ctx, i)
+	}
+
+	podList, err := PodsCreatedBy(i.Namespace, f.kubernetes, ctx, i.GetStatefulSetName())
+	if err != nil {
+		return nil, err
+	}
+	return f.NewInfinispanForPod(ctx, podList.Items[0].Name, i)
+}
+
+// NewStreamingInfinispan returns a new api.Infinispan client backed by a native.Client connected to the cluster's admin
+// service, regardless of the configured InfinispanHttpClient. Response bodies are not buffered, so this client should be
+// used for operations that return large responses, such as api.Cache Keys and Entries which fail with any other client.
+func (f *InfinispanClientFactory) NewStreamingInfinispan(ctx context.Context, i *v1.Infinispan) (api.Infinispan, error) {
+	host := fmt.Sprintf("%s.%s.svc", i.GetAdminServiceName(), i.Namespace)
+	if f.httpClient != nil {
+		return f.newInfinispanFromFactory(host, i)
+	}
+	nativeClient, err := NewNativeClient(ctx, host, i, f.kubernetes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create Infinispan client: %w", err)
+	}
+	return NewInfinispanForVersion(i, nativeClient), nil
+}
+
+// NewInfinispanForPod returns a api.Infinispan implementation that communicates with the provided pod
+func (f *InfinispanClientFactory) NewInfinispanForPod(ctx context.Context, podName string, i *v1.Infinispan) (api.Infinispan, error) {
+	c, err := f.NewHttpClientForPod(ctx, podName, i)
 	if err != nil {
 		return nil, err
 	}
-	return NewInfinispanForPod(ctx, podList.Items[0].Name, i, kubernetes)
+	return NewInfinispanForVersion(i, c), nil
+}
+
+// NewHttpClientForPod retrieves credential information to initialise a curl.Client, or a native.Client when configured,
+// that communicates with the provided pod
+func (f *InfinispanClientFactory) NewHttpClientForPod(ctx context.Context, podName string, i *v1.Infinispan) (http.HttpClient, error) {
+	if f.httpClient != nil {
+		c, err := f.httpClient(podName, i)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create Infinispan client: %w", err)
+		}
+		return c, nil
+	}
+
+	if InfinispanHttpClient == HttpClientNative {
+		pod := &corev1.Pod{}
+		if err := f.kubernetes.Client.Get(ctx, types.NamespacedName{Namespace: i.Namespace, Name: podName}, pod); err != nil {
+			return nil, fmt.Errorf("unable to retrieve pod '%s' when creating Infinispan client: %w", podName, err)
+		}
+		if pod.Status.PodIP == "" {
+			return nil, fmt.Errorf("unable to create Infinispan client, pod '%s' has no IP address assigned", podName)
+		}
+		nativeClient, err := NewNativeClient(ctx, pod.Status.PodIP, i, f.kubernetes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create Infinispan client: %w", err)
+		}
+		return nativeClient, nil
+	}
+
+	curl, err := NewCurlClient(ctx, podName, i, f.kubernetes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create Infinispan client: %w", err)
+	}
+	return curl, nil
 }
 
-// NewInfinispanForPod retrieves credential information to initialise a curl.Client and uses this to return a api.Infinispan implementation
-func NewInfinispanForPod(ctx context.Context, podName string, i *v1.Infinispan, kubernetes *kube.Kubernetes) (api.Infinispan, error) {
-	curl, err := NewCurlClient(ctx, podName, i, kubernetes)
+func (f *InfinispanClientFactory) newInfinispanFromFactory(host string, i *v1.Infinispan) (api.Infinispan, error) {
+	c, err := f.httpClient(host, i)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create Infinispan client: %w", err)
 	}
-	return client.New(curl), nil
+	return NewInfinispanForVersion(i, c), nil
 }
 
 // NewCurlClient return a new curl.Client using the admin credentials associated with the v1.Infinispan instance
@@ -51,12 +155,46 @@ func NewCurlClient(ctx context.Context, podName string, i *v1.Infinispan, kubern
 	return curlClient, nil
 }
 
+// NewNativeClient return a new native.Client that connects to the admin endpoint of the provided host using the admin
+// credentials associated with the v1.Infinispan instance
+func NewNativeClient(ctx context.Context, host string, i *v1.Infinispan, kubernetes *kube.Kubernetes) (*native.Client, error) {
+	pass, err := users.AdminPassword(i.GetAdminSecretName(), i.Namespace, kubernetes, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve operator admin identities when creating native client: %w", err)
+	}
+	nativeClient := native.New(native.Config{
+		Credentials: &native.Credentials{
+			Username: consts.DefaultOperatorUser,
+			Password: pass,
+		},
+		Host: host,
+		Port: consts.InfinispanAdminPort,
+		// The admin endpoint does not use TLS, even if endpoint encryption is enabled, as the admin security-realm
+		// in the server configuration does not define a server identity
+		Protocol: "http",
+	})
+	return nativeClient, nil
+}
+
 // InfinispanForPod return a api.Infinispan based upon a clone of the provided curl.Client that uses the provided podname
-// This method should be preferred over NewInfinispanForPod when a curl.Client already exists in order to prevent duplicate
-// lookups of the admin credentials
-func InfinispanForPod(podName string, c *curl.Client) api.Infinispan {
+// This method should be preferred over InfinispanClientFactory.NewInfinispanForPod when a curl.Client already exists in
+// order to prevent duplicate lookups of the admin credentials
+func InfinispanForPod(podName string, i *v1.Infinispan, c *curl.Client) api.Infinispan {
 	cloneConfig := c.Config
 	cloneConfig.Podname = podName
 	curl := curl.New(cloneConfig, c.Kubernetes)
-	return client.New(curl)
+	return NewInfinispanForVersion(i, curl)
+}
+
+// NewInfinispanForVersion returns the api.Infinispan implementation matching the operand version of the v1.Infinispan instance.
+// The server version is only detected via the provided http.HttpClient if the operand version cannot be determined, i.e.
+// the image has no version tag and the version reported by the server has not been recorded in the status yet.
+func NewInfinispanForVersion(i *v1.Infinispan, c http.HttpClient) api.Infinispan {
+	log := clientLog.WithValues("infinispan", types.NamespacedName{Namespace: i.Namespace, Name: i.Name})
+	v, err := i.OperandVersion()
+	if err != nil {
+		log.Info("unable to determine the operand version, detecting the server version", "reason", err.Error())
+		return client.New(c, log)
+	}
+	return client.NewForVersionOrDefault(v, c, log)
 }
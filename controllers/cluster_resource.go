@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	v1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// clusterResource provides the plumbing shared by the reconcilers of CRs that define a resource on the server of the
+// Infinispan cluster referenced by the CR's spec.clusterName, i.e. Counter, Schema and Task CRs
+type clusterResource struct {
+	client    client.Client
+	ctx       context.Context
+	object    client.Object
+	reqLogger logr.Logger
+	// kind the lower case kind of the CR, e.g. "counter"
+	kind string
+	// setReady sets the Ready condition of the CR
+	setReady func(status metav1.ConditionStatus, message string)
+}
+
+// update applies mutate to the CR and patches it, returning a NotFound error if the CR no longer exists
+func (r *clusterResource) update(mutate func() error) error {
+	obj := r.object
+	_, err := kube.CreateOrPatch(r.ctx, r.client, obj, func() error {
+		if creationTimestamp := obj.GetCreationTimestamp(); creationTimestamp.IsZero() {
+			return errors.NewNotFound(schema.ParseGroupResource(r.kind+".infinispan.org"), obj.GetName())
+		}
+		return mutate()
+	})
+	if err != nil {
+		return fmt.Errorf("unable to update %s %s: %w", r.kind, obj.GetName(), err)
+	}
+	return nil
+}
+
+// updateReady sets the Ready condition of the CR. The finalizer is added once the CR is ready if finalizer is true, so
+// that the resource is removed from the server when the CR is deleted
+func (r *clusterResource) updateReady(status metav1.ConditionStatus, message string, finalizer bool) error {
+	return r.update(func() error {
+		r.setReady(status, message)
+		if finalizer && status == metav1.ConditionTrue {
+			controllerutil.AddFinalizer(r.object, constants.InfinispanFinalizer)
+		}
+		return nil
+	})
+}
+
+func (r *clusterResource) removeFinalizer() error {
+	return r.update(func() error {
+		controllerutil.RemoveFinalizer(r.object, constants.InfinispanFinalizer)
+		return nil
+	})
+}
+
+// finalize removes the resource from the server with remove before removing the finalizer from the deleted CR
+func (r *clusterResource) finalize(remove func() error) error {
+	if !controllerutil.ContainsFinalizer(r.object, constants.InfinispanFinalizer) {
+		return nil
+	}
+	if err := remove(); err != nil {
+		return err
+	}
+	return r.removeFinalizer()
+}
+
+// configMapValue returns the value of the key referenced by ref in a ConfigMap in the CR's namespace. A NotFound error is
+// returned if the ConfigMap does not exist
+func (r *clusterResource) configMapValue(ref *corev1.ConfigMapKeySelector) (string, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := r.client.Get(r.ctx, types.NamespacedName{Namespace: r.object.GetNamespace(), Name: ref.Name}, configMap); err != nil {
+		return "", err
+	}
+	value, ok := configMap.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("key '%s' not found in ConfigMap '%s'", ref.Key, ref.Name)
+	}
+	return value, nil
+}
+
+// infinispanClient returns a client for the Infinispan cluster with the provided name. A nil client is returned if the
+// cluster does not exist or is not well formed, in which case the request should not be requeued as the Infinispan watch
+// ensures that a request is queued when the cluster is updated. The finalizer is removed from deleted CRs if the cluster
+// no longer exists, as the resource no longer exists on the server
+func (r *clusterResource) infinispanClient(clusterName string, clients *InfinispanClientFactory) (api.Infinispan, error) {
+	infinispan := &v1.Infinispan{}
+	if err := r.client.Get(r.ctx, types.NamespacedName{Namespace: r.object.GetNamespace(), Name: clusterName}, infinispan); err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+		r.reqLogger.Error(err, fmt.Sprintf("Infinispan cluster %s not found", clusterName))
+		if r.object.GetDeletionTimestamp() != nil {
+			return nil, r.removeFinalizer()
+		}
+		// Set the Ready condition to false in case the cluster was previously WellFormed
+		return nil, r.updateReady(metav1.ConditionFalse, "", false)
+	}
+
+	if !infinispan.IsWellFormed() {
+		r.reqLogger.Info(fmt.Sprintf("Infinispan cluster %s not well formed", infinispan.Name))
+		return nil, nil
+	}
+
+	ispnClient, err := clients.NewInfinispan(r.ctx, infinispan)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create Infinispan client: %w", err)
+	}
+	return ispnClient, nil
+}
+
+// watchClusterResources indexes the CRs of the type handled by the builder by spec.clusterName, so that a request is
+// queued for each CR that references an Infinispan cluster when the cluster is updated. newList returns an empty list of
+// the CR type and clusterName the name of the cluster referenced by a CR
+func watchClusterResources(ctx context.Context, mgr ctrl.Manager, b *builder.Builder, kubernetes *kube.Kubernetes, log logr.Logger,
+	obj client.Object, newList func() client.ObjectList, clusterName func(client.Object) string) error {
+	if err := mgr.GetFieldIndexer().IndexField(ctx, obj, "spec.clusterName", func(obj client.Object) []string {
+		return []string{clusterName(obj)}
+	}); err != nil {
+		return err
+	}
+
+	b.Watches(
+		&source.Kind{Type: &v1.Infinispan{}},
+		handler.EnqueueRequestsFromMapFunc(
+			func(a client.Object) []reconcile.Request {
+				i := a.(*v1.Infinispan)
+				// Only enqueue requests once a Infinispan CR has the WellFormed condition or it has been deleted
+				if !i.HasCondition(v1.ConditionWellFormed) || !a.GetDeletionTimestamp().IsZero() {
+					return nil
+				}
+				return clusterResourceRequests(ctx, kubernetes, log, newList(), a.GetNamespace(), "spec.clusterName", a.GetName())
+			}),
+	)
+	return nil
+}
+
+// watchConfigMapKeyRefs indexes the CRs of the type handled by the builder by spec.configMapKeyRef.name, so that a
+// request is queued for each CR that references a ConfigMap when the ConfigMap is updated. configMapName returns the
+// name of the ConfigMap referenced by a CR, or an empty string if no ConfigMap is referenced
+func watchConfigMapKeyRefs(ctx context.Context, mgr ctrl.Manager, b *builder.Builder, kubernetes *kube.Kubernetes, log logr.Logger,
+	obj client.Object, newList func() client.ObjectList, configMapName func(client.Object) string) error {
+	if err := mgr.GetFieldIndexer().IndexField(ctx, obj, "spec.configMapKeyRef.name", func(obj client.Object) []string {
+		if name := configMapName(obj); name != "" {
+			return []string{name}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	b.Watches(
+		&source.Kind{Type: &corev1.ConfigMap{}},
+		handler.EnqueueRequestsFromMapFunc(
+			func(a client.Object) []reconcile.Request {
+				return clusterResourceRequests(ctx, kubernetes, log, newList(), a.GetNamespace(), "spec.configMapKeyRef.name", a.GetName())
+			}),
+	)
+	return nil
+}
+
+// clusterResourceRequests returns a request for each CR in list whose indexed field has the provided value
+func clusterResourceRequests(ctx context.Context, kubernetes *kube.Kubernetes, log logr.Logger, list client.ObjectList, namespace, field, value string) []reconcile.Request {
+	if err := kubernetes.ResourcesListByField(namespace, field, value, list, ctx); err != nil {
+		log.Error(err, "watches failed to list CRs", "field", field, "value", value)
+		return nil
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		log.Error(err, "watches failed to extract listed CRs")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, item := range items {
+		if obj, ok := item.(client.Object); ok {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}})
+		}
+	}
+	return requests
+}
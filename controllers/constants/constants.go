@@ -131,6 +131,13 @@ const (
 	DefaultWaitClusterNotWellFormed = 15 * time.Second
 	// DefaultWaitPodsNotReady wait delay until cluster pods are ready
 	DefaultWaitClusterPodsNotReady = 2 * time.Second
+	// DefaultCacheStatsRefreshPeriod period between updates of the statistics published in the Cache CR status
+	DefaultCacheStatsRefreshPeriod = 60 * time.Second
+	// DefaultCacheAvailabilityCheckPeriod period between checks for degraded caches when automatic recovery is enabled
+	DefaultCacheAvailabilityCheckPeriod = 30 * time.Second
+	// DefaultRebalancingSuspendedTimeout maximum period that rebalancing is suspended whilst scaling up, after which it is
+	// resumed even if the new pods have not joined the cluster
+	DefaultRebalancingSuspendedTimeout = 10 * time.Minute
 )
 
 const (
@@ -153,6 +160,7 @@ const (
 	AnnotationDomain             = "infinispan.org/"
 	ListenerAnnotationGeneration = AnnotationDomain + "listener-generation"
 	ListenerAnnotationDelete     = AnnotationDomain + "listener-delete"
+	BackupScheduleLabel          = AnnotationDomain + "backup-schedule"
 )
 
 // GetWithDefault return value if not empty else return defValue
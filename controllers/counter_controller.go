@@ -0,0 +1,195 @@
+package controllers
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	v2alpha1 "github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CounterReconciler reconciles a Counter object
+type CounterReconciler struct {
+	client.Client
+	log        logr.Logger
+	scheme     *runtime.Scheme
+	kubernetes *kube.Kubernetes
+	clients    *InfinispanClientFactory
+	eventRec   record.EventRecorder
+}
+
+type counterRequest struct {
+	*CounterReconciler
+	*clusterResource
+	counter    *v2alpha1.Counter
+	ispnClient api.Infinispan
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CounterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	r.Client = mgr.GetClient()
+	r.log = ctrl.Log.WithName("controllers").WithName("Counter")
+	r.scheme = mgr.GetScheme()
+	r.kubernetes = kube.NewKubernetesFromController(mgr)
+	r.clients = NewInfinispanClientFactory(r.kubernetes)
+	r.eventRec = mgr.GetEventRecorderFor("counter-controller")
+
+	builder := ctrl.NewControllerManagedBy(mgr).For(&v2alpha1.Counter{})
+	err := watchClusterResources(ctx, mgr, builder, r.kubernetes, r.log, &v2alpha1.Counter{},
+		func() client.ObjectList { return &v2alpha1.CounterList{} },
+		func(obj client.Object) string { return obj.(*v2alpha1.Counter).Spec.ClusterName },
+	)
+	if err != nil {
+		return err
+	}
+	return builder.Complete(r)
+}
+
+// +kubebuilder:rbac:groups=infinispan.org,namespace=infinispan-operator-system,resources=counters;counters/status;counters/finalizers,verbs=get;list;watch;create;update;patch;delete
+
+func (r *CounterReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	reqLogger := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("+++++ Reconciling Counter.")
+	defer reqLogger.Info("----- End Reconciling Counter.")
+
+	// Fetch the Counter instance
+	instance := &v2alpha1.Counter{}
+	if err := r.Client.Get(ctx, request.NamespacedName, instance); err != nil {
+		if errors.IsNotFound(err) {
+			reqLogger.Info("Counter resource not found. Ignoring it since the object must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	counter := &counterRequest{
+		CounterReconciler: r,
+		clusterResource: &clusterResource{
+			client:    r.Client,
+			ctx:       ctx,
+			object:    instance,
+			reqLogger: reqLogger,
+			kind:      "counter",
+			setReady: func(status metav1.ConditionStatus, message string) {
+				instance.SetCondition(v2alpha1.CounterConditionReady, status, message)
+			},
+		},
+		counter: instance,
+	}
+
+	ispnClient, err := counter.infinispanClient(instance.Spec.ClusterName, r.clients)
+	if ispnClient == nil {
+		return ctrl.Result{}, err
+	}
+	counter.ispnClient = ispnClient
+
+	if instance.GetDeletionTimestamp() != nil {
+		// Remove Deleted counters from the server before removing the Finalizer
+		return ctrl.Result{}, counter.finalize(func() error {
+			return ispnClient.Counters().Delete(instance.GetCounterName())
+		})
+	}
+
+	if err := counter.ispnCreateOrUpdate(); err != nil {
+		if err == errCounterImmutable {
+			// Retrying will not succeed until the spec is reverted, which triggers a new reconciliation
+			return ctrl.Result{}, counter.updateReady(metav1.ConditionFalse, err.Error(), false)
+		}
+		reqLogger.Error(err, "Unable to reconcile Counter")
+		return ctrl.Result{Requeue: true}, counter.updateReady(metav1.ConditionFalse, err.Error(), false)
+	}
+	return ctrl.Result{}, counter.updateReady(metav1.ConditionTrue, "", true)
+}
+
+// errCounterImmutable is returned when the Counter spec differs from the definition of the existing counter
+var errCounterImmutable = stderrors.New("counter definition is immutable")
+
+// ispnCreateOrUpdate creates the counter on the server if it doesn't exist. Counter definitions cannot be updated and
+// recreating the counter would reset its value, so errCounterImmutable is returned if the definition on the server
+// differs from the CR
+func (r *counterRequest) ispnCreateOrUpdate() error {
+	name := r.counter.GetCounterName()
+	counters := r.ispnClient.Counters()
+
+	desired, err := counterConfig(&r.counter.Spec)
+	if err != nil {
+		return err
+	}
+
+	existing, err := counters.Config(name)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve counter configuration: %w", err)
+	}
+
+	if existing != nil {
+		if counterConfigEqual(existing, desired) {
+			return nil
+		}
+		r.eventRec.Event(r.counter, corev1.EventTypeWarning, "CounterImmutable", fmt.Sprintf("Counter '%s' is not updated as its definition cannot be changed", name))
+		return errCounterImmutable
+	}
+
+	if err := counters.Create(name, desired); err != nil {
+		return fmt.Errorf("unable to create counter: %w", err)
+	}
+	return nil
+}
+
+func counterConfig(spec *v2alpha1.CounterSpec) (*api.CounterConfig, error) {
+	config := &api.CounterConfig{
+		InitialValue: spec.InitialValue,
+		Storage:      api.CounterStorageVolatile,
+	}
+
+	if spec.Storage == v2alpha1.CounterStoragePersistent {
+		config.Storage = api.CounterStoragePersistent
+	}
+
+	switch spec.Type {
+	case v2alpha1.CounterTypeWeak:
+		if spec.UpperBound != nil || spec.LowerBound != nil {
+			return nil, fmt.Errorf("'spec.upperBound' and 'spec.lowerBound' are only supported by %s counters", v2alpha1.CounterTypeStrong)
+		}
+		config.Type = api.CounterTypeWeak
+		config.ConcurrencyLevel = spec.ConcurrencyLevel
+	case v2alpha1.CounterTypeStrong, "":
+		if spec.ConcurrencyLevel != nil {
+			return nil, fmt.Errorf("'spec.concurrencyLevel' is only supported by %s counters", v2alpha1.CounterTypeWeak)
+		}
+		config.Type = api.CounterTypeStrong
+		config.UpperBound = spec.UpperBound
+		config.LowerBound = spec.LowerBound
+	default:
+		return nil, fmt.Errorf("unknown counter type '%s'", spec.Type)
+	}
+	return config, nil
+}
+
+// counterConfigEqual compares the server configuration with the desired configuration. Optional fields that are not
+// defined in the desired configuration are ignored, as the server populates them with default values
+func counterConfigEqual(existing, desired *api.CounterConfig) bool {
+	if existing.Type != desired.Type || existing.InitialValue != desired.InitialValue || existing.Storage != desired.Storage {
+		return false
+	}
+	if desired.UpperBound != nil && !reflect.DeepEqual(existing.UpperBound, desired.UpperBound) {
+		return false
+	}
+	if desired.LowerBound != nil && !reflect.DeepEqual(existing.LowerBound, desired.LowerBound) {
+		return false
+	}
+	if desired.ConcurrencyLevel != nil && !reflect.DeepEqual(existing.ConcurrencyLevel, desired.ConcurrencyLevel) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/fake"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func testCounter(spec v2alpha1.CounterSpec) *v2alpha1.Counter {
+	spec.ClusterName = "example"
+	return &v2alpha1.Counter{
+		ObjectMeta: testObjectMeta("counter"),
+		Spec:       spec,
+	}
+}
+
+func TestCounterReconcilerCreatesCounter(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	upperBound := int64(10)
+	counter := testCounter(v2alpha1.CounterSpec{InitialValue: 5, UpperBound: &upperBound})
+	k8sClient, clients := testClients(t, server, testInfinispan(), counter)
+	r := CounterReconciler(newTestReconciler(k8sClient, clients))
+
+	request := testRequest(counter)
+	_, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+
+	server.Update(func(s *fake.State) {
+		assert.Contains(t, s.Counters, "counter")
+		config := s.Counters["counter"].Config
+		assert.Equal(t, api.CounterTypeStrong, config.Type)
+		assert.Equal(t, api.CounterStorageVolatile, config.Storage)
+		assert.Equal(t, int64(5), config.InitialValue)
+		assert.Equal(t, &upperBound, config.UpperBound)
+	})
+
+	updated := &v2alpha1.Counter{}
+	assert.NoError(t, k8sClient.Get(context.TODO(), request.NamespacedName, updated))
+	assert.Contains(t, updated.Finalizers, constants.InfinispanFinalizer)
+	assert.Equal(t, []v2alpha1.CounterCondition{{Type: v2alpha1.CounterConditionReady, Status: metav1.ConditionTrue}}, updated.Status.Conditions)
+}
+
+func TestCounterReconcilerDoesNotUpdateChangedCounter(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	server.Update(func(s *fake.State) {
+		s.Counters["counter"] = &fake.Counter{
+			Config: api.CounterConfig{Type: api.CounterTypeStrong, Storage: api.CounterStorageVolatile, InitialValue: 1},
+			Value:  3,
+		}
+	})
+
+	counter := testCounter(v2alpha1.CounterSpec{Type: v2alpha1.CounterTypeWeak, InitialValue: 1})
+	k8sClient, clients := testClients(t, server, testInfinispan(), counter)
+	r := CounterReconciler(newTestReconciler(k8sClient, clients))
+
+	request := testRequest(counter)
+	result, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.False(t, result.Requeue)
+
+	// The counter is left untouched so that its value is not reset
+	server.Update(func(s *fake.State) {
+		assert.Equal(t, api.CounterTypeStrong, s.Counters["counter"].Config.Type)
+		assert.Equal(t, int64(3), s.Counters["counter"].Value)
+	})
+	updated := &v2alpha1.Counter{}
+	assert.NoError(t, k8sClient.Get(context.TODO(), request.NamespacedName, updated))
+	assert.Equal(t, []v2alpha1.CounterCondition{{Type: v2alpha1.CounterConditionReady, Status: metav1.ConditionFalse, Message: "counter definition is immutable"}}, updated.Status.Conditions)
+	assert.Len(t, r.eventRec.(*record.FakeRecorder).Events, 1)
+}
+
+func TestCounterReconcilerInvalidSpec(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	concurrencyLevel := int32(4)
+	counter := testCounter(v2alpha1.CounterSpec{Type: v2alpha1.CounterTypeStrong, ConcurrencyLevel: &concurrencyLevel})
+	k8sClient, clients := testClients(t, server, testInfinispan(), counter)
+	r := CounterReconciler(newTestReconciler(k8sClient, clients))
+
+	request := testRequest(counter)
+	result, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.True(t, result.Requeue)
+
+	server.Update(func(s *fake.State) {
+		assert.Empty(t, s.Counters)
+	})
+	updated := &v2alpha1.Counter{}
+	assert.NoError(t, k8sClient.Get(context.TODO(), request.NamespacedName, updated))
+	assert.NotContains(t, updated.Finalizers, constants.InfinispanFinalizer)
+	assert.Equal(t, metav1.ConditionFalse, updated.Status.Conditions[0].Status)
+	assert.Equal(t, "'spec.concurrencyLevel' is only supported by Weak counters", updated.Status.Conditions[0].Message)
+}
+
+func TestCounterReconcilerDeletesCounter(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	server.Update(func(s *fake.State) {
+		s.Counters["counter"] = &fake.Counter{Config: api.CounterConfig{Type: api.CounterTypeStrong}}
+	})
+
+	now := metav1.Now()
+	counter := testCounter(v2alpha1.CounterSpec{})
+	counter.DeletionTimestamp = &now
+	counter.Finalizers = []string{constants.InfinispanFinalizer}
+	k8sClient, clients := testClients(t, server, testInfinispan(), counter)
+	r := CounterReconciler(newTestReconciler(k8sClient, clients))
+
+	request := testRequest(counter)
+	_, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+
+	server.Update(func(s *fake.State) {
+		assert.NotContains(t, s.Counters, "counter")
+	})
+	updated := &v2alpha1.Counter{}
+	assert.NoError(t, k8sClient.Get(context.TODO(), request.NamespacedName, updated))
+	assert.NotContains(t, updated.Finalizers, constants.InfinispanFinalizer)
+}
+
+func TestCounterReconcilerClusterNotFound(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	counter := testCounter(v2alpha1.CounterSpec{})
+	counter.SetCondition(v2alpha1.CounterConditionReady, metav1.ConditionTrue, "")
+	k8sClient, clients := testClients(t, server, counter)
+	r := CounterReconciler(newTestReconciler(k8sClient, clients))
+
+	request := testRequest(counter)
+	result, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.False(t, result.Requeue)
+
+	updated := &v2alpha1.Counter{}
+	assert.NoError(t, k8sClient.Get(context.TODO(), request.NamespacedName, updated))
+	assert.Equal(t, metav1.ConditionFalse, updated.Status.Conditions[0].Status)
+}
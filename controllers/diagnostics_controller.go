@@ -0,0 +1,583 @@
+package controllers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	DiagnosticsDataMountPath = "/opt/infinispan/diagnostics"
+
+	diagnosticsArchiveKey       = "diagnostics.tar.gz"
+	diagnosticsWriterContainer  = "writer"
+	diagnosticsVolumeName       = "diagnostics"
+	maxDiagnosticsConfigMapSize = 1000 * 1024
+	// The writer pod terminates once its lifetime has elapsed. A pod is only used to store an archive if the collection
+	// started at least diagnosticsWriterMinRemaining before the pod's deadline
+	diagnosticsWriterLifetime     = time.Hour
+	diagnosticsWriterMinRemaining = 10 * time.Minute
+
+	EventReasonDiagnosticsCollected = "DiagnosticsCollected"
+	EventReasonDiagnosticsFailed    = "DiagnosticsFailed"
+)
+
+// DiagnosticsReconciler reconciles a Diagnostics object
+type DiagnosticsReconciler struct {
+	client.Client
+	log        logr.Logger
+	scheme     *runtime.Scheme
+	kubernetes *kube.Kubernetes
+	clients    *InfinispanClientFactory
+	eventRec   record.EventRecorder
+}
+
+type diagnosticsRequest struct {
+	*DiagnosticsReconciler
+	ctx         context.Context
+	diagnostics *v2alpha1.Diagnostics
+	infinispan  *v1.Infinispan
+	reqLogger   logr.Logger
+}
+
+// diagnosticsArchive a gzipped tar archive containing the collected artifacts
+type diagnosticsArchive struct {
+	buf       bytes.Buffer
+	gzip      *gzip.Writer
+	tar       *tar.Writer
+	artifacts []v2alpha1.DiagnosticsArtifact
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DiagnosticsReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Client = mgr.GetClient()
+	r.log = ctrl.Log.WithName("controllers").WithName("Diagnostics")
+	r.scheme = mgr.GetScheme()
+	r.kubernetes = kube.NewKubernetesFromController(mgr)
+	r.clients = NewInfinispanClientFactory(r.kubernetes)
+	r.eventRec = mgr.GetEventRecorderFor("diagnostics-controller")
+
+	// Watch the writer pod so that the archive is stored as soon as the pod is ready
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v2alpha1.Diagnostics{}).
+		Owns(&corev1.Pod{}).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=infinispan.org,namespace=infinispan-operator-system,resources=diagnostics;diagnostics/status;diagnostics/finalizers,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=core,namespace=infinispan-operator-system,resources=pods/log,verbs=get
+// +kubebuilder:rbac:groups=core,namespace=infinispan-operator-system,resources=events,verbs=get;list;watch
+
+func (r *DiagnosticsReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	reqLogger := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("+++++ Reconciling Diagnostics.")
+	defer reqLogger.Info("----- End Reconciling Diagnostics.")
+
+	instance := &v2alpha1.Diagnostics{}
+	if err := r.Client.Get(ctx, request.NamespacedName, instance); err != nil {
+		if errors.IsNotFound(err) {
+			reqLogger.Info("Diagnostics resource not found. Ignoring it since the object must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Diagnostics are only collected once
+	if instance.IsCompleted() {
+		return ctrl.Result{}, nil
+	}
+
+	diagnostics := &diagnosticsRequest{
+		DiagnosticsReconciler: r,
+		ctx:                   ctx,
+		diagnostics:           instance,
+		reqLogger:             reqLogger,
+	}
+
+	infinispan := &v1.Infinispan{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: instance.Spec.Cluster}, infinispan); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, diagnostics.fail(fmt.Errorf("infinispan cluster '%s' not found", instance.Spec.Cluster))
+		}
+		return ctrl.Result{}, err
+	}
+	diagnostics.infinispan = infinispan
+
+	if instance.Status.Phase == "" {
+		if err := diagnostics.update(func() {
+			instance.Status.Phase = v2alpha1.DiagnosticsInitializing
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Ensure that the writer pod is ready before collecting diagnostics, so that the archive can be stored immediately
+	var writerDeadline time.Time
+	if instance.Spec.Volume != nil {
+		deadline, ready, err := diagnostics.initVolume()
+		if err != nil {
+			return ctrl.Result{}, diagnostics.fail(err)
+		} else if !ready {
+			reqLogger.Info("Waiting for diagnostics writer pod to become ready")
+			return ctrl.Result{RequeueAfter: consts.DefaultWaitOnCreateResource}, nil
+		}
+		writerDeadline = deadline
+	}
+
+	archive, err := diagnostics.collect()
+	if err != nil {
+		return ctrl.Result{}, diagnostics.fail(err)
+	}
+
+	// The writer pod may have terminated if the collection took longer than expected, in which case the pod is recreated
+	// and the diagnostics collected again
+	if instance.Spec.Volume != nil && time.Until(writerDeadline) < time.Minute {
+		reqLogger.Info("Diagnostics collection outlived the writer pod, recreating the pod", "deadline", writerDeadline)
+		if err := diagnostics.deleteWriterPod(); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: consts.DefaultWaitOnCreateResource}, nil
+	}
+
+	location, err := diagnostics.store(archive)
+	if err != nil {
+		return ctrl.Result{}, diagnostics.fail(err)
+	}
+
+	if instance.Spec.Volume != nil {
+		if err := diagnostics.deleteWriterPod(); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	r.eventRec.Event(instance, corev1.EventTypeNormal, EventReasonDiagnosticsCollected, fmt.Sprintf("Diagnostics archive stored in %s", location))
+	return ctrl.Result{}, diagnostics.update(func() {
+		instance.Status.Phase = v2alpha1.DiagnosticsSucceeded
+		instance.Status.Reason = ""
+		instance.Status.Archive = location
+		instance.Status.Artifacts = archive.artifacts
+		instance.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	})
+}
+
+func (r *diagnosticsRequest) update(mutate func()) error {
+	diagnostics := r.diagnostics
+	_, err := kube.CreateOrPatch(r.ctx, r.Client, diagnostics, func() error {
+		if diagnostics.CreationTimestamp.IsZero() {
+			return errors.NewNotFound(schema.ParseGroupResource("diagnostics.infinispan.org"), diagnostics.Name)
+		}
+		mutate()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to update diagnostics %s: %w", diagnostics.Name, err)
+	}
+	return nil
+}
+
+func (r *diagnosticsRequest) fail(err error) error {
+	r.reqLogger.Error(err, "Unable to collect diagnostics")
+	r.eventRec.Event(r.diagnostics, corev1.EventTypeWarning, EventReasonDiagnosticsFailed, err.Error())
+	if r.diagnostics.Spec.Volume != nil {
+		if err := r.deleteWriterPod(); err != nil {
+			r.reqLogger.Error(err, "Unable to delete diagnostics writer pod")
+		}
+	}
+	return r.update(func() {
+		r.diagnostics.Status.Phase = v2alpha1.DiagnosticsFailed
+		r.diagnostics.Status.Reason = err.Error()
+	})
+}
+
+// collect retrieves the server report, thread dump, logs, members and health of every pod in the cluster, as well as
+// the Kubernetes resources that define the cluster. Failing to collect an artifact is recorded in the archive's
+// artifacts, so that a misbehaving pod does not prevent the remaining diagnostics from being collected.
+func (r *diagnosticsRequest) collect() (*diagnosticsArchive, error) {
+	archive := newDiagnosticsArchive()
+	ispn := r.infinispan
+
+	podList, err := PodsCreatedBy(ispn.Namespace, r.kubernetes, r.ctx, ispn.GetStatefulSetName())
+	if err != nil {
+		return nil, fmt.Errorf("unable to list cluster pods: %w", err)
+	}
+
+	for _, pod := range podList.Items {
+		podName := pod.Name
+		dir := fmt.Sprintf("pods/%s/", podName)
+
+		logs, err := r.kubernetes.Logs(podName, ispn.Namespace, r.ctx)
+		if err := archive.add(dir+"server.log", podName, []byte(logs), err); err != nil {
+			return nil, err
+		}
+
+		serverArtifacts := []string{"report.tar.gz", "threaddump.txt", "members.json", "health.txt"}
+		if !kube.IsPodReady(pod) {
+			for _, artifact := range serverArtifacts {
+				if err := archive.add(dir+artifact, podName, nil, fmt.Errorf("pod not ready")); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		ispnClient, err := r.clients.NewInfinispanForPod(r.ctx, podName, ispn)
+		if err != nil {
+			for _, artifact := range serverArtifacts {
+				if err := archive.add(dir+artifact, podName, nil, err); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		report, err := ispnClient.Server().Report()
+		if err := archive.add(dir+"report.tar.gz", podName, report, err); err != nil {
+			return nil, err
+		}
+
+		threadDump, err := ispnClient.Server().ThreadDump()
+		if err := archive.add(dir+"threaddump.txt", podName, []byte(threadDump), err); err != nil {
+			return nil, err
+		}
+
+		var membersJson []byte
+		members, err := ispnClient.Container().Members()
+		if err == nil {
+			membersJson, err = json.MarshalIndent(members, "", "  ")
+		}
+		if err := archive.add(dir+"members.json", podName, membersJson, err); err != nil {
+			return nil, err
+		}
+
+		health, err := ispnClient.Container().HealthStatus()
+		if err := archive.add(dir+"health.txt", podName, []byte(health), err); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.collectResources(archive, podList); err != nil {
+		return nil, err
+	}
+	return archive, archive.close()
+}
+
+// collectResources adds the YAML of the Kubernetes resources related to the cluster. Secrets are never included.
+func (r *diagnosticsRequest) collectResources(archive *diagnosticsArchive, podList *corev1.PodList) error {
+	ispn := r.infinispan
+	ns := ispn.Namespace
+
+	resources := map[string]func() (runtime.Object, error){
+		"infinispan.yaml": func() (runtime.Object, error) {
+			return ispn, nil
+		},
+		"pods.yaml": func() (runtime.Object, error) {
+			return podList, nil
+		},
+		"statefulset.yaml": func() (runtime.Object, error) {
+			sts := &appsv1.StatefulSet{}
+			return sts, r.Client.Get(r.ctx, types.NamespacedName{Namespace: ns, Name: ispn.GetStatefulSetName()}, sts)
+		},
+		"configmap.yaml": func() (runtime.Object, error) {
+			configMap := &corev1.ConfigMap{}
+			return configMap, r.Client.Get(r.ctx, types.NamespacedName{Namespace: ns, Name: ispn.GetConfigName()}, configMap)
+		},
+		"services.yaml": func() (runtime.Object, error) {
+			services := &corev1.ServiceList{}
+			return services, r.kubernetes.ResourcesList(ns, LabelsResource(ispn.Name, ""), services, r.ctx)
+		},
+		"events.yaml": func() (runtime.Object, error) {
+			events := &corev1.EventList{}
+			if err := r.Client.List(r.ctx, events, client.InNamespace(ns)); err != nil {
+				return nil, err
+			}
+			return clusterEvents(events, ispn.Name), nil
+		},
+	}
+
+	for _, name := range []string{"infinispan.yaml", "statefulset.yaml", "pods.yaml", "configmap.yaml", "services.yaml", "events.yaml"} {
+		var content []byte
+		obj, err := resources[name]()
+		if err == nil {
+			content, err = yaml.Marshal(obj)
+		}
+		if err := archive.add("kubernetes/"+name, "", content, err); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clusterEvents returns the events related to the cluster and the resources named after it, such as its pods
+func clusterEvents(events *corev1.EventList, cluster string) *corev1.EventList {
+	filtered := &corev1.EventList{}
+	for _, event := range events.Items {
+		if name := event.InvolvedObject.Name; name == cluster || strings.HasPrefix(name, cluster+"-") {
+			filtered.Items = append(filtered.Items, event)
+		}
+	}
+	return filtered
+}
+
+// store writes the archive to the configured PersistentVolumeClaim or ConfigMap, returning the archive's location
+func (r *diagnosticsRequest) store(archive *diagnosticsArchive) (string, error) {
+	diagnostics := r.diagnostics
+	content := archive.buf.Bytes()
+
+	if diagnostics.Spec.Volume != nil {
+		file := fmt.Sprintf("%s-%s.tar.gz", diagnostics.Name, time.Now().UTC().Format("20060102T150405Z"))
+		_, err := r.kubernetes.ExecWithOptions(kube.ExecOptions{
+			Container: diagnosticsWriterContainer,
+			Command:   []string{"sh", "-c", fmt.Sprintf("cat > %s/%s", DiagnosticsDataMountPath, file)},
+			Namespace: diagnostics.Namespace,
+			PodName:   r.writerPodName(),
+			Stdin:     bytes.NewReader(content),
+		})
+		if err != nil {
+			return "", fmt.Errorf("unable to write diagnostics archive to volume: %w", err)
+		}
+		return fmt.Sprintf("pvc/%s/%s", diagnostics.GetClaimName(), file), nil
+	}
+
+	if len(content) > maxDiagnosticsConfigMapSize {
+		return "", fmt.Errorf("diagnostics archive of %d bytes exceeds the maximum ConfigMap size, configure spec.volume to store the archive in a PersistentVolumeClaim", len(content))
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      diagnostics.Name,
+			Namespace: diagnostics.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(r.ctx, r.Client, configMap, func() error {
+		configMap.BinaryData = map[string][]byte{diagnosticsArchiveKey: content}
+		return controllerutil.SetControllerReference(diagnostics, configMap, r.scheme)
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to create diagnostics ConfigMap: %w", err)
+	}
+	return fmt.Sprintf("configmap/%s", configMap.Name), nil
+}
+
+// initVolume creates the PersistentVolumeClaim if required and the pod used to write the archive to it, returning true
+// once the pod is ready along with the time by which the archive must be written before the pod terminates
+func (r *diagnosticsRequest) initVolume() (time.Time, bool, error) {
+	if err := r.getOrCreatePvc(); err != nil {
+		return time.Time{}, false, err
+	}
+
+	pod := &corev1.Pod{}
+	err := r.Client.Get(r.ctx, types.NamespacedName{Namespace: r.diagnostics.Namespace, Name: r.writerPodName()}, pod)
+	if err == nil {
+		if pod.Status.Phase == corev1.PodFailed || pod.Status.Phase == corev1.PodSucceeded {
+			// The pod terminated before the archive could be written, so recreate it
+			return time.Time{}, false, r.deleteWriterPod()
+		}
+		if pod.DeletionTimestamp != nil || !kube.IsPodReady(*pod) {
+			return time.Time{}, false, nil
+		}
+		deadline := writerPodDeadline(pod)
+		if time.Until(deadline) < diagnosticsWriterMinRemaining {
+			// The pod may terminate before the diagnostics have been collected, so recreate it
+			r.reqLogger.Info("Diagnostics writer pod is about to terminate, recreating the pod", "deadline", deadline)
+			return time.Time{}, false, r.deleteWriterPod()
+		}
+		return deadline, true, nil
+	}
+	if !errors.IsNotFound(err) {
+		return time.Time{}, false, err
+	}
+
+	pod = r.writerPodSpec()
+	if err := controllerutil.SetControllerReference(r.diagnostics, pod, r.scheme); err != nil {
+		return time.Time{}, false, err
+	}
+	if err := r.Client.Create(r.ctx, pod); err != nil {
+		return time.Time{}, false, fmt.Errorf("unable to create diagnostics writer pod: %w", err)
+	}
+	return time.Time{}, false, nil
+}
+
+// writerPodDeadline returns the time at which the writer pod terminates. The creation time is used if the pod has not
+// been started yet, which is always earlier than the start time
+func writerPodDeadline(pod *corev1.Pod) time.Time {
+	start := pod.CreationTimestamp.Time
+	if pod.Status.StartTime != nil {
+		start = pod.Status.StartTime.Time
+	}
+	return start.Add(diagnosticsWriterLifetime)
+}
+
+func (r *diagnosticsRequest) getOrCreatePvc() error {
+	diagnostics := r.diagnostics
+	volumeSpec := diagnostics.Spec.Volume
+	pvc := &corev1.PersistentVolumeClaim{}
+	err := r.Client.Get(r.ctx, types.NamespacedName{Namespace: diagnostics.Namespace, Name: diagnostics.GetClaimName()}, pvc)
+	if err == nil {
+		return nil
+	}
+	if !errors.IsNotFound(err) {
+		return err
+	}
+
+	// Existing claims are never created by the operator
+	if volumeSpec.ClaimName != "" {
+		return fmt.Errorf("persistentVolumeClaim '%s' not found", volumeSpec.ClaimName)
+	}
+
+	storage := consts.DefaultPVSize
+	if volumeSpec.Storage != nil {
+		if storage, err = resource.ParseQuantity(*volumeSpec.Storage); err != nil {
+			return err
+		}
+	}
+
+	pvc = &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      diagnostics.GetClaimName(),
+			Namespace: diagnostics.Namespace,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{
+				corev1.ReadWriteOnce,
+			},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: storage,
+				},
+			},
+			StorageClassName: volumeSpec.StorageClassName,
+		},
+	}
+	if err = controllerutil.SetControllerReference(diagnostics, pvc, r.scheme); err != nil {
+		return err
+	}
+	if err = r.Client.Create(r.ctx, pvc); err != nil {
+		return fmt.Errorf("unable to create pvc: %w", err)
+	}
+	return nil
+}
+
+func (r *diagnosticsRequest) writerPodName() string {
+	return r.diagnostics.Name + "-diagnostics"
+}
+
+// writerPodSpec returns a pod that mounts the diagnostics volume and idles until the archive has been written to it
+func (r *diagnosticsRequest) writerPodSpec() *corev1.Pod {
+	diagnostics := r.diagnostics
+	labels := LabelsResource(diagnostics.Spec.Cluster, "infinispan-diagnostics-pod")
+	labels["diagnostics_cr"] = diagnostics.Name
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.writerPodName(),
+			Namespace: diagnostics.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:    diagnosticsWriterContainer,
+				Image:   consts.InitContainerImageName,
+				Command: []string{"sh", "-c", fmt.Sprintf("sleep %d", int(diagnosticsWriterLifetime.Seconds()))},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      diagnosticsVolumeName,
+					MountPath: DiagnosticsDataMountPath,
+				}},
+			}},
+			RestartPolicy: corev1.RestartPolicyNever,
+			Volumes: []corev1.Volume{{
+				Name: diagnosticsVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: diagnostics.GetClaimName(),
+					},
+				},
+			}},
+		},
+	}
+	AddVolumeChmodInitContainer("diagnostics-chmod-pv", diagnosticsVolumeName, DiagnosticsDataMountPath, &pod.Spec)
+	return pod
+}
+
+func (r *diagnosticsRequest) deleteWriterPod() error {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.writerPodName(),
+			Namespace: r.diagnostics.Namespace,
+		},
+	}
+	if err := r.Client.Delete(r.ctx, pod); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("unable to delete diagnostics writer pod: %w", err)
+	}
+	return nil
+}
+
+func newDiagnosticsArchive() *diagnosticsArchive {
+	a := &diagnosticsArchive{}
+	a.gzip = gzip.NewWriter(&a.buf)
+	a.tar = tar.NewWriter(a.gzip)
+	return a
+}
+
+// add writes the content to the archive if collectErr is nil, otherwise the failure is only recorded in the artifacts.
+// An error is only returned if the archive itself cannot be written.
+func (a *diagnosticsArchive) add(path, pod string, content []byte, collectErr error) error {
+	artifact := v2alpha1.DiagnosticsArtifact{
+		Path: path,
+		Pod:  pod,
+	}
+	if collectErr != nil {
+		artifact.Error = collectErr.Error()
+		a.artifacts = append(a.artifacts, artifact)
+		return nil
+	}
+
+	header := &tar.Header{
+		Name:    path,
+		Mode:    0644,
+		Size:    int64(len(content)),
+		ModTime: time.Now(),
+	}
+	if err := a.tar.WriteHeader(header); err != nil {
+		return fmt.Errorf("unable to write '%s' to diagnostics archive: %w", path, err)
+	}
+	if _, err := a.tar.Write(content); err != nil {
+		return fmt.Errorf("unable to write '%s' to diagnostics archive: %w", path, err)
+	}
+	artifact.Size = header.Size
+	a.artifacts = append(a.artifacts, artifact)
+	return nil
+}
+
+func (a *diagnosticsArchive) close() error {
+	if err := a.tar.Close(); err != nil {
+		return fmt.Errorf("unable to close diagnostics archive: %w", err)
+	}
+	if err := a.gzip.Close(); err != nil {
+		return fmt.Errorf("unable to close diagnostics archive: %w", err)
+	}
+	return nil
+}
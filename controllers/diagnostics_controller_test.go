@@ -0,0 +1,213 @@
+package controllers
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/fake"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newTestDiagnosticsRequest(t *testing.T, diagnostics *v2alpha1.Diagnostics, objs ...client.Object) *diagnosticsRequest {
+	server := fake.NewServer()
+	t.Cleanup(server.Close)
+
+	infinispan := testInfinispan()
+	k8sClient, clients := testClients(t, server, append(objs, infinispan, diagnostics)...)
+	return &diagnosticsRequest{
+		DiagnosticsReconciler: &DiagnosticsReconciler{
+			Client:     k8sClient,
+			log:        logger,
+			scheme:     k8sClient.Scheme(),
+			kubernetes: &kube.Kubernetes{Client: k8sClient},
+			clients:    clients,
+			eventRec:   record.NewFakeRecorder(10),
+		},
+		ctx:         context.TODO(),
+		diagnostics: diagnostics,
+		infinispan:  infinispan,
+		reqLogger:   logger,
+	}
+}
+
+func testDiagnostics(volume *v2alpha1.DiagnosticsVolumeSpec) *v2alpha1.Diagnostics {
+	return &v2alpha1.Diagnostics{
+		ObjectMeta: testObjectMeta("diagnostics"),
+		Spec:       v2alpha1.DiagnosticsSpec{Cluster: "example", Volume: volume},
+	}
+}
+
+// readDiagnosticsArchive returns the content of each file in the archive keyed by path
+func readDiagnosticsArchive(t *testing.T, archive *diagnosticsArchive) map[string]string {
+	gz, err := gzip.NewReader(&archive.buf)
+	assert.NoError(t, err)
+	reader := tar.NewReader(gz)
+	files := map[string]string{}
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return files
+		}
+		assert.NoError(t, err)
+		content, err := ioutil.ReadAll(reader)
+		assert.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+}
+
+func TestDiagnosticsArchive(t *testing.T) {
+	archive := newDiagnosticsArchive()
+	assert.NoError(t, archive.add("pods/example-0/health.txt", "example-0", []byte("HEALTHY"), nil))
+	// Failing to collect an artifact is only recorded in the artifacts
+	assert.NoError(t, archive.add("pods/example-1/health.txt", "example-1", nil, fmt.Errorf("pod not ready")))
+	assert.NoError(t, archive.add("kubernetes/infinispan.yaml", "", []byte{}, nil))
+	assert.NoError(t, archive.close())
+
+	assert.Equal(t, []v2alpha1.DiagnosticsArtifact{
+		{Path: "pods/example-0/health.txt", Pod: "example-0", Size: 7},
+		{Path: "pods/example-1/health.txt", Pod: "example-1", Error: "pod not ready"},
+		{Path: "kubernetes/infinispan.yaml"},
+	}, archive.artifacts)
+	assert.Equal(t, map[string]string{
+		"pods/example-0/health.txt":  "HEALTHY",
+		"kubernetes/infinispan.yaml": "",
+	}, readDiagnosticsArchive(t, archive))
+
+	// Nothing can be added once the archive has been closed
+	assert.Error(t, archive.add("pods/example-2/health.txt", "example-2", []byte("HEALTHY"), nil))
+}
+
+func TestDiagnosticsCollectResources(t *testing.T) {
+	event := func(name, involvedObject string) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: namespace},
+			InvolvedObject: corev1.ObjectReference{Name: involvedObject},
+		}
+	}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "example-generated-secret", Namespace: namespace}}
+	r := newTestDiagnosticsRequest(t, testDiagnostics(nil), secret,
+		event("cluster", "example"),
+		event("pod", "example-0"),
+		event("other-cluster", "example2-0"),
+		event("other", "unrelated"),
+	)
+
+	archive := newDiagnosticsArchive()
+	assert.NoError(t, r.collectResources(archive, &corev1.PodList{}))
+	assert.NoError(t, archive.close())
+
+	// Resources that do not exist are recorded as failed artifacts and Secrets are never collected
+	var paths []string
+	for _, artifact := range archive.artifacts {
+		paths = append(paths, artifact.Path)
+		if artifact.Path == "kubernetes/statefulset.yaml" || artifact.Path == "kubernetes/configmap.yaml" {
+			assert.NotEmpty(t, artifact.Error)
+		} else {
+			assert.Empty(t, artifact.Error)
+		}
+	}
+	assert.Equal(t, []string{"kubernetes/infinispan.yaml", "kubernetes/statefulset.yaml", "kubernetes/pods.yaml",
+		"kubernetes/configmap.yaml", "kubernetes/services.yaml", "kubernetes/events.yaml"}, paths)
+
+	files := readDiagnosticsArchive(t, archive)
+	for _, content := range files {
+		assert.NotContains(t, content, secret.Name)
+	}
+	assert.Contains(t, files["kubernetes/events.yaml"], "name: cluster\n")
+	assert.Contains(t, files["kubernetes/events.yaml"], "name: pod\n")
+	assert.NotContains(t, files["kubernetes/events.yaml"], "other")
+}
+
+func TestClusterEvents(t *testing.T) {
+	events := &corev1.EventList{}
+	for _, name := range []string{"example", "example-0", "example-config", "example2", "example2-0", "exam"} {
+		events.Items = append(events.Items, corev1.Event{InvolvedObject: corev1.ObjectReference{Name: name}})
+	}
+	var names []string
+	for _, event := range clusterEvents(events, "example").Items {
+		names = append(names, event.InvolvedObject.Name)
+	}
+	assert.Equal(t, []string{"example", "example-0", "example-config"}, names)
+}
+
+func TestDiagnosticsStoreConfigMap(t *testing.T) {
+	diagnostics := testDiagnostics(nil)
+	r := newTestDiagnosticsRequest(t, diagnostics)
+
+	archive := &diagnosticsArchive{}
+	archive.buf.WriteString("archive")
+	location, err := r.store(archive)
+	assert.NoError(t, err)
+	assert.Equal(t, "configmap/diagnostics", location)
+
+	configMap := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: diagnostics.Name}, configMap))
+	assert.Equal(t, map[string][]byte{diagnosticsArchiveKey: []byte("archive")}, configMap.BinaryData)
+	assert.Equal(t, diagnostics.Name, configMap.OwnerReferences[0].Name)
+}
+
+func TestDiagnosticsStoreConfigMapSizeLimit(t *testing.T) {
+	diagnostics := testDiagnostics(nil)
+	diagnostics.Name = "large"
+	r := newTestDiagnosticsRequest(t, diagnostics)
+
+	archive := &diagnosticsArchive{}
+	archive.buf.Write(make([]byte, maxDiagnosticsConfigMapSize))
+	_, err := r.store(archive)
+	assert.NoError(t, err)
+
+	archive.buf.WriteByte(0)
+	_, err = r.store(archive)
+	assert.EqualError(t, err, fmt.Sprintf("diagnostics archive of %d bytes exceeds the maximum ConfigMap size, configure spec.volume to store the archive in a PersistentVolumeClaim", maxDiagnosticsConfigMapSize+1))
+}
+
+func TestDiagnosticsWriterPodDeadline(t *testing.T) {
+	diagnostics := testDiagnostics(&v2alpha1.DiagnosticsVolumeSpec{})
+	writerPod := func(started time.Time) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "diagnostics-diagnostics", Namespace: namespace},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				StartTime:  &metav1.Time{Time: started},
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+
+	// Times are serialized with second precision
+	started := time.Now().Add(-time.Minute).Truncate(time.Second)
+	r := newTestDiagnosticsRequest(t, diagnostics, writerPod(started))
+	deadline, ready, err := r.initVolume()
+	assert.NoError(t, err)
+	assert.True(t, ready)
+	assert.True(t, started.Add(diagnosticsWriterLifetime).Equal(deadline))
+
+	// A pod that terminates before the diagnostics can be collected is deleted so that it's recreated
+	r = newTestDiagnosticsRequest(t, diagnostics, writerPod(time.Now().Add(-diagnosticsWriterLifetime+time.Minute)))
+	_, ready, err = r.initVolume()
+	assert.NoError(t, err)
+	assert.False(t, ready)
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: r.writerPodName()}, &corev1.Pod{})
+	assert.True(t, errors.IsNotFound(err))
+
+	_, ready, err = r.initVolume()
+	assert.NoError(t, err)
+	assert.False(t, ready)
+	pod := &corev1.Pod{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: r.writerPodName()}, pod))
+	assert.Equal(t, []string{"sh", "-c", "sleep 3600"}, pod.Spec.Containers[0].Command)
+}
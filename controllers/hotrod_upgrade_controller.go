@@ -12,11 +12,13 @@ import (
 	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
 	. "github.com/infinispan/infinispan-operator/controllers/constants"
 	"github.com/infinispan/infinispan-operator/pkg/hash"
-	"github.com/infinispan/infinispan-operator/pkg/http/curl"
+	"github.com/infinispan/infinispan-operator/pkg/http"
 	ispnClient "github.com/infinispan/infinispan-operator/pkg/infinispan/client"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
 	"github.com/infinispan/infinispan-operator/pkg/infinispan/configuration/logging"
 	users "github.com/infinispan/infinispan-operator/pkg/infinispan/security"
 	"github.com/infinispan/infinispan-operator/pkg/infinispan/upgrades"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/version"
 	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -38,6 +40,7 @@ type HotRodRollingUpgradeReconciler struct {
 	scheme     *runtime.Scheme
 	log        logr.Logger
 	kubernetes *kube.Kubernetes
+	clients    *InfinispanClientFactory
 	eventRec   record.EventRecorder
 }
 
@@ -46,8 +49,8 @@ type HotRodRollingUpgradeRequest struct {
 	ctx        context.Context
 	infinispan *ispnv1.Infinispan
 	reqLogger  logr.Logger
-	// Curl client for the source statefulset
-	curl               *curl.Client
+	// Http client for the source statefulset
+	source             http.HttpClient
 	currentStatefulSet string
 }
 
@@ -60,6 +63,7 @@ func (r *HotRodRollingUpgradeReconciler) SetupWithManager(mgr ctrl.Manager) erro
 	r.log = ctrl.Log.WithName("controllers").WithName(strings.Title(name))
 	r.scheme = mgr.GetScheme()
 	r.kubernetes = kube.NewKubernetesFromController(mgr)
+	r.clients = NewInfinispanClientFactory(r.kubernetes)
 	r.eventRec = mgr.GetEventRecorderFor(name + "-controller")
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -145,9 +149,9 @@ func (r *HotRodRollingUpgradeReconciler) Reconcile(ctx context.Context, request
 		}
 	}
 
-	curl, err := NewCurlClient(ctx, podList.Items[0].Name, ispn, r.kubernetes)
+	source, err := r.clients.NewHttpClientForPod(ctx, podList.Items[0].Name, ispn)
 	if err != nil {
-		return reconcile.Result{}, fmt.Errorf("unable to create curl client: %w", err)
+		return reconcile.Result{}, err
 	}
 
 	req := HotRodRollingUpgradeRequest{
@@ -155,7 +159,7 @@ func (r *HotRodRollingUpgradeReconciler) Reconcile(ctx context.Context, request
 		ctx:                            ctx,
 		infinispan:                     ispn,
 		reqLogger:                      reqLogger,
-		curl:                           curl,
+		source:                         source,
 		currentStatefulSet:             podList.Items[0].Labels[StatefulSetPodLabel],
 	}
 
@@ -323,7 +327,9 @@ func (r *HotRodRollingUpgradeRequest) createNewStatefulSet() (ctrl.Result, error
 	}
 
 	// Check if cluster is well-formed
-	conditions := getInfinispanConditions(podList.Items, r.infinispan, r.curl)
+	conditions := getInfinispanConditions(podList.Items, r.infinispan, func(podName string) (api.Infinispan, error) {
+		return r.clients.NewInfinispanForPod(r.ctx, podName, r.infinispan)
+	})
 	r.reqLogger.Info(fmt.Sprintf("Cluster conditions: %v", conditions))
 	for _, condition := range conditions {
 		if condition.Type == ispnv1.ConditionWellFormed {
@@ -366,9 +372,11 @@ func (r *HotRodRollingUpgradeRequest) prepare() (ctrl.Result, error) {
 		return reconcile.Result{}, err
 	}
 
-	sourceClient := ispnClient.New(r.curl)
-	// Clone the source curl client as the credentials are the same, updating the pod to one from the target statefulset
-	targetClient := InfinispanForPod(podNameTarget, r.curl)
+	sourceClient := r.sourceInfinispan()
+	targetClient, err := r.clients.NewInfinispanForPod(r.ctx, podNameTarget, r.infinispan)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
 
 	ip := sourceClusterService.Spec.ClusterIP
 	if err = upgrades.ConnectCaches(pass, ip, sourceClient, targetClient, r.reqLogger); err != nil {
@@ -406,8 +414,10 @@ func (r *HotRodRollingUpgradeRequest) syncData() (ctrl.Result, error) {
 		return reconcile.Result{}, fmt.Errorf("failed to obtain pods from the target cluster: %w", err)
 	}
 
-	// Clone the source curl client as the credentials are the same, updating the pod to one from the target statefulset
-	targetClient := InfinispanForPod(podList.Items[0].Name, r.curl)
+	targetClient, err := r.clients.NewInfinispanForPod(r.ctx, podList.Items[0].Name, r.infinispan)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
 	if err = upgrades.SyncCaches(targetClient, r.reqLogger); err != nil {
 		return reconcile.Result{}, err
 	}
@@ -662,8 +672,7 @@ func (r *HotRodRollingUpgradeRequest) reconcileNewConfigMap() (*corev1.ConfigMap
 		Categories: r.infinispan.GetLogCategoriesForConfig(),
 	}
 
-	// TODO utilise the version of the server being upgraded to once server/operator versions decoupled
-	log4jXml, err := logging.Generate(nil, loggingConfig)
+	log4jXml, err := logging.Generate(OperandTemplateVersion(r.infinispan, r.reqLogger), loggingConfig)
 	if err != nil {
 		return nil, reconcile.Result{}, err
 	}
@@ -686,3 +695,15 @@ func (r *HotRodRollingUpgradeRequest) rollback() (reconcile.Result, error) {
 	// Rollback is needed when state is cleanup, since the statefulSet in the CRD was replaced
 	return r.cleanup(status.TargetStatefulSetName, status.Stage == ispnv1.HotRodRollingStageCleanup)
 }
+
+// sourceInfinispan returns the api.Infinispan client for the source cluster. The operand version recorded in the status
+// still corresponds to the source cluster, as it is only updated once all pods are running the desired image, so the
+// server version is only detected if no version has been recorded
+func (r *HotRodRollingUpgradeRequest) sourceInfinispan() api.Infinispan {
+	if operand := r.infinispan.Status.Operand; operand != nil && operand.Version != "" {
+		if v, err := version.FromString(operand.Version); err == nil {
+			return ispnClient.NewForVersionOrDefault(v, r.source, r.reqLogger)
+		}
+	}
+	return ispnClient.New(r.source, r.reqLogger)
+}
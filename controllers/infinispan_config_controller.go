@@ -10,6 +10,7 @@ import (
 	consts "github.com/infinispan/infinispan-operator/controllers/constants"
 	"github.com/infinispan/infinispan-operator/pkg/infinispan/configuration/logging"
 	config "github.com/infinispan/infinispan-operator/pkg/infinispan/configuration/server"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/version"
 	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -122,8 +123,7 @@ func (reconciler *ConfigReconciler) Reconcile(ctx context.Context, request recon
 		Categories: infinispan.GetLogCategoriesForConfig(),
 	}
 
-	// TODO utilise a version specific logging once server/operator versions decoupled
-	log4jXml, err := logging.Generate(nil, loggingSpec)
+	log4jXml, err := logging.Generate(OperandTemplateVersion(infinispan, reqLogger), loggingSpec)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -247,14 +247,38 @@ func GenerateServerConfig(statefulSet string, i *v1.Infinispan, kubernetes *kube
 		}
 	}
 
-	// TODO utilise a version specific configurator once server/operator versions decoupled
-	config, err := config.Generate(nil, configSpec)
+	config, err := config.Generate(OperandTemplateVersion(i, log), configSpec)
 	if err != nil {
 		return "", &reconcile.Result{}, err
 	}
 	return config, nil, nil
 }
 
+// latestTemplateVersion is the newest server version with its own configuration templates
+var latestTemplateVersion = version.Version{Major: 14}
+
+// OperandTemplateVersion returns the server version used to select the configuration templates. Nil is returned, so that
+// the default templates are used, if the version cannot be determined or the image tag does not correspond to an
+// Infinispan version, e.g. product images that are versioned independently of the server. Versions newer than
+// latestTemplateVersion use the latest templates
+func OperandTemplateVersion(i *v1.Infinispan, log logr.Logger) *version.Version {
+	v, err := i.OperandVersion()
+	if err != nil {
+		log.Info("unable to determine the server version, using default configuration templates", "reason", err.Error())
+		return nil
+	}
+	if v.Major < 13 {
+		log.Info("image version does not correspond to a server version, using default configuration templates", "version", v.String())
+		return nil
+	}
+	if v.Major > latestTemplateVersion.Major {
+		log.Info("no configuration templates for server version, using the latest templates", "version", v.String(), "templates", latestTemplateVersion.String())
+		latest := latestTemplateVersion
+		return &latest
+	}
+	return v
+}
+
 func IsUserProvidedKeystore(secret *corev1.Secret) bool {
 	_, userKeystore := secret.Data["keystore.p12"]
 	return userKeystore
@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	"testing"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/configuration/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperandTemplateVersion(t *testing.T) {
+	templateVersion := func(image string) string {
+		ispn := &ispnv1.Infinispan{Spec: ispnv1.InfinispanSpec{Image: &image}}
+		v := OperandTemplateVersion(ispn, logger)
+		if v == nil {
+			return ""
+		}
+		return v.String()
+	}
+	assert.Equal(t, "13.0.10", templateVersion("quay.io/infinispan/server:13.0.10.Final"))
+	assert.Equal(t, "14.0.0", templateVersion("quay.io/infinispan/server:14.0"))
+	// Newer servers use the latest templates
+	assert.Equal(t, "14.0.0", templateVersion("quay.io/infinispan/server:15.1"))
+	// Images that are not versioned by server version use the default templates
+	assert.Equal(t, "", templateVersion("registry.redhat.io/datagrid/datagrid-8-rhel8:1.3"))
+	assert.Equal(t, "", templateVersion("quay.io/infinispan/server"))
+
+	image := "quay.io/infinispan/server:15.0"
+	ispn := &ispnv1.Infinispan{Spec: ispnv1.InfinispanSpec{Image: &image}}
+	_, err := logging.Generate(OperandTemplateVersion(ispn, logger), &logging.Spec{})
+	assert.NoError(t, err)
+}
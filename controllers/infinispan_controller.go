@@ -14,6 +14,7 @@ import (
 	hash "github.com/infinispan/infinispan-operator/pkg/hash"
 	"github.com/infinispan/infinispan-operator/pkg/http/curl"
 	ispnApi "github.com/infinispan/infinispan-operator/pkg/infinispan/client"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
 	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
 	"github.com/infinispan/infinispan-operator/pkg/mime"
 	routev1 "github.com/openshift/api/route/v1"
@@ -62,6 +63,13 @@ const (
 	EventReasonEphemeralStorage      = "EphemeralStorageEnables"
 	EventReasonParseValueProblem     = "ParseValueProblem"
 	EventLoadBalancerUnsupported     = "LoadBalancerUnsupported"
+	EventReasonCacheRecovered        = "CacheAvailabilityRestored"
+	EventReasonCacheRecoveryFailed   = "CacheAvailabilityRestoreFailed"
+	EventReasonRebalancingTimeout    = "RebalancingSuspendedTimeout"
+
+	EventReasonCrossSiteStateTransferStarted   = "CrossSiteStateTransferStarted"
+	EventReasonCrossSiteStateTransferSucceeded = "CrossSiteStateTransferSucceeded"
+	EventReasonCrossSiteStateTransferFailed    = "CrossSiteStateTransferFailed"
 
 	SiteTransportKeystoreVolumeName = "encrypt-transport-site-tls-volume"
 	SiteRouterKeystoreVolumeName    = "encrypt-router-site-tls-volume"
@@ -74,6 +82,7 @@ type InfinispanReconciler struct {
 	log            logr.Logger
 	scheme         *runtime.Scheme
 	kubernetes     *kube.Kubernetes
+	clients        *InfinispanClientFactory
 	eventRec       record.EventRecorder
 	supportedTypes map[string]*reconcileType
 }
@@ -93,6 +102,7 @@ func (r *InfinispanReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.log = ctrl.Log.WithName("controllers").WithName("Infinispan")
 	r.scheme = mgr.GetScheme()
 	r.kubernetes = kube.NewKubernetesFromController(mgr)
+	r.clients = NewInfinispanClientFactory(r.kubernetes)
 	r.eventRec = mgr.GetEventRecorderFor("controller-infinispan")
 	r.supportedTypes = map[string]*reconcileType{
 		consts.ExternalTypeRoute:   {ObjectType: &routev1.Route{}, GroupVersion: routev1.SchemeGroupVersion, GroupVersionSupported: false},
@@ -550,6 +560,11 @@ func (reconciler *InfinispanReconciler) Reconcile(ctx context.Context, ctrlReque
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	res, err = r.reconcileScaling(statefulSet, podList)
+	if res != nil {
+		return *res, err
+	}
+
 	// Here where to reconcile with spec updates that reflect into
 	// changes to statefulset.spec.container.
 	res, err = r.reconcileContainerConf(statefulSet, configMap, overlayConfigMap, overlayConfigMapKey, overlayLog4jConfig, adminSecret, userSecret, keystoreSecret, trustSecret)
@@ -581,7 +596,9 @@ func (reconciler *InfinispanReconciler) Reconcile(ctx context.Context, ctrlReque
 	}
 
 	// Inspect the system and get the current Infinispan conditions
-	currConds := getInfinispanConditions(podList.Items, infinispan, curl)
+	currConds := getInfinispanConditions(podList.Items, infinispan, func(podName string) (api.Infinispan, error) {
+		return InfinispanForPod(podName, infinispan, curl), nil
+	})
 
 	// Update the Infinispan status with the pod status
 	if err := r.update(func() {
@@ -597,6 +614,10 @@ func (reconciler *InfinispanReconciler) Reconcile(ctx context.Context, ctrlReque
 	}
 
 	// Below the code for a wellFormed cluster
+	if err = r.reconcileOperandVersion(podList, curl); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if err = configureLoggers(podList, infinispan, curl); err != nil {
 		return ctrl.Result{}, err
 	}
@@ -612,7 +633,7 @@ func (reconciler *InfinispanReconciler) Reconcile(ctx context.Context, ctrlReque
 		}
 	}
 
-	ispnClient := ispnApi.New(curl)
+	ispnClient := NewInfinispanForVersion(infinispan, curl)
 	// Create default cache if it doesn't exists.
 	if infinispan.IsCache() {
 		cacheClient := ispnClient.Cache(consts.DefaultCacheName)
@@ -633,6 +654,22 @@ func (reconciler *InfinispanReconciler) Reconcile(ctx context.Context, ctrlReque
 		}
 	}
 
+	if infinispan.IsConditionTrue(infinispanv1.ConditionRebalancingSuspended) {
+		if res, err := r.resumeRebalancing(ispnClient); res != nil {
+			return *res, err
+		}
+	}
+
+	if infinispan.IsCacheAutoRecoveryEnabled() {
+		if err := r.reconcileCacheAvailability(ispnClient); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else if err := r.update(func() {
+		infinispan.RemoveCondition(infinispanv1.ConditionCachesAvailable)
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if infinispan.IsExposed() {
 		var exposeAddress string
 		switch infinispan.GetExposeType() {
@@ -696,7 +733,7 @@ func (reconciler *InfinispanReconciler) Reconcile(ctx context.Context, ctrlReque
 	}
 
 	if infinispan.HasSites() {
-		crossSiteViewCondition, err := r.GetCrossSiteViewCondition(podList, infinispan.GetSiteLocationsName(), curl)
+		crossSiteViewCondition, sitesView, err := r.GetCrossSiteViewCondition(podList, infinispan.GetSiteLocationsName(), curl)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
@@ -708,22 +745,102 @@ func (reconciler *InfinispanReconciler) Reconcile(ctx context.Context, ctrlReque
 				log.Error(err, fmt.Sprintf("Unable to retrive logs for infinispan pod %s", podName))
 			}
 			if strings.Contains(logs, "ISPN000643") {
-				if err := InfinispanForPod(podName, curl).Container().Xsite().PushAllState(); err != nil {
+				if err := InfinispanForPod(podName, infinispan, curl).Container().Xsite().PushAllState(); err != nil {
 					log.Error(err, "Unable to push xsite state after SFS data recovery")
 				}
 			}
 		}
+		sites := infinispan.Status.Sites
+		if crossSiteViewCondition.Status == metav1.ConditionTrue {
+			if sites, err = GetCrossSiteStatus(ispnClient.Container().Xsite(), sites); err != nil {
+				return ctrl.Result{}, fmt.Errorf("unable to retrieve cross-site status: %w", err)
+			}
+		}
+		stateTransferInProgress := false
+		if infinispan.IsCrossSiteStateTransferEnabled() {
+			var stateTransferErr error
+			sites, stateTransferInProgress, stateTransferErr = r.reconcileCrossSiteStateTransfer(sites, sitesView, ispnClient)
+			if stateTransferErr != nil {
+				reqLogger.Error(stateTransferErr, "Unable to reconcile cross-site state transfer")
+			}
+		}
 		err = r.update(func() {
 			infinispan.SetConditions([]infinispanv1.InfinispanCondition{*crossSiteViewCondition})
+			infinispan.Status.Sites = sites
 		})
-		if err != nil || crossSiteViewCondition.Status != metav1.ConditionTrue {
+		if err != nil || crossSiteViewCondition.Status != metav1.ConditionTrue || stateTransferInProgress {
 			return ctrl.Result{RequeueAfter: consts.DefaultWaitOnCluster}, err
 		}
+	} else if infinispan.Status.Sites != nil {
+		if err := r.update(func() {
+			infinispan.Status.Sites = nil
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 
+	if infinispan.IsCacheAutoRecoveryEnabled() {
+		// A healed network partition does not necessarily trigger a reconciliation, so periodically check for degraded caches
+		return ctrl.Result{RequeueAfter: consts.DefaultCacheAvailabilityCheckPeriod}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
+// reconcileCacheAvailability restores caches in DEGRADED_MODE to AVAILABLE. This must only be called once all pods have
+// joined the cluster view, so that the partition that caused the caches to become degraded is known to have healed.
+func (r *infinispanRequest) reconcileCacheAvailability(ispnClient api.Infinispan) error {
+	infinispan := r.infinispan
+	health, err := ispnClient.Container().HealthStatus()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve cluster health: %w", err)
+	}
+
+	// Degraded caches are the only cause of a DEGRADED health status once the cluster view has formed, so there's
+	// no need to inspect the individual caches otherwise
+	if health != api.HealthStatusDegraded {
+		return r.update(func() {
+			infinispan.SetCondition(infinispanv1.ConditionCachesAvailable, metav1.ConditionTrue, "")
+		})
+	}
+
+	cacheNames, err := ispnClient.Caches().Names()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve cache names: %w", err)
+	}
+
+	var recovered, failed []string
+	for _, cacheName := range cacheNames {
+		cache := ispnClient.Cache(cacheName)
+		availability, err := cache.Availability()
+		if err != nil {
+			return fmt.Errorf("unable to retrieve availability of cache '%s': %w", cacheName, err)
+		}
+		if availability != api.CacheAvailabilityDegraded {
+			continue
+		}
+
+		if err := cache.SetAvailability(api.CacheAvailabilityAvailable); err != nil {
+			r.reqLogger.Error(err, "unable to restore cache availability", "cache", cacheName)
+			r.eventRec.Event(infinispan, corev1.EventTypeWarning, EventReasonCacheRecoveryFailed, fmt.Sprintf("Unable to restore cache '%s' to AVAILABLE: %v", cacheName, err))
+			failed = append(failed, cacheName)
+			continue
+		}
+		r.reqLogger.Info("Restored degraded cache to AVAILABLE", "cache", cacheName)
+		r.eventRec.Event(infinispan, corev1.EventTypeNormal, EventReasonCacheRecovered, fmt.Sprintf("Cache '%s' restored from DEGRADED_MODE to AVAILABLE", cacheName))
+		recovered = append(recovered, cacheName)
+	}
+
+	return r.update(func() {
+		if len(failed) > 0 {
+			infinispan.SetCondition(infinispanv1.ConditionCachesAvailable, metav1.ConditionFalse, "Unable to restore caches: "+strings.Join(failed, ","))
+		} else if len(recovered) > 0 {
+			infinispan.SetCondition(infinispanv1.ConditionCachesAvailable, metav1.ConditionTrue, "Restored caches: "+strings.Join(recovered, ","))
+		} else {
+			infinispan.SetCondition(infinispanv1.ConditionCachesAvailable, metav1.ConditionTrue, "")
+		}
+	})
+}
+
 // PreliminaryChecks performs all the possible initial checks
 func (r *infinispanRequest) preliminaryChecks() (*ctrl.Result, error) {
 	// If a CacheService is requested, checks that the pods have enough memory
@@ -751,13 +868,38 @@ func (r *infinispanRequest) preliminaryChecks() (*ctrl.Result, error) {
 	return nil, nil
 }
 
+// reconcileOperandVersion records the version reported by the server once all pods are running the desired image, so that
+// the client implementation and configuration templates agree on the running server version
+func (r *infinispanRequest) reconcileOperandVersion(podList *corev1.PodList, curl *curl.Client) error {
+	infinispan := r.infinispan
+	image := GetContainer(InfinispanContainer, &podList.Items[0].Spec).Image
+	if image != infinispan.ImageName() {
+		return nil
+	}
+	if operand := infinispan.Status.Operand; operand != nil && operand.Image == image && operand.Version != "" {
+		return nil
+	}
+
+	serverVersion, err := ispnApi.ServerVersion(curl)
+	if err != nil {
+		return err
+	}
+	r.reqLogger.Info("detected Infinispan server version", "image", image, "version", serverVersion.String())
+	return r.update(func() {
+		infinispan.Status.Operand = &infinispanv1.OperandStatus{
+			Image:   image,
+			Version: serverVersion.String(),
+		}
+	})
+}
+
 func configureLoggers(pods *corev1.PodList, infinispan *infinispanv1.Infinispan, curl *curl.Client) error {
 	if infinispan.Spec.Logging == nil || len(infinispan.Spec.Logging.Categories) == 0 {
 		return nil
 	}
 
 	for _, pod := range pods.Items {
-		logging := InfinispanForPod(pod.Name, curl).Logging()
+		logging := InfinispanForPod(pod.Name, infinispan, curl).Logging()
 		serverLoggers, err := logging.GetLoggers()
 		if err != nil {
 			return err
@@ -1389,8 +1531,17 @@ func (r *infinispanRequest) statefulSetForInfinispan(adminSecret, userSecret, ke
 	return dep, nil
 }
 
-// getInfinispanConditions returns the pods status and a summary status for the cluster
-func getInfinispanConditions(pods []corev1.Pod, m *infinispanv1.Infinispan, curl *curl.Client) []infinispanv1.InfinispanCondition {
+func podMembers(podName string, clientForPod func(podName string) (api.Infinispan, error)) ([]string, error) {
+	ispnClient, err := clientForPod(podName)
+	if err != nil {
+		return nil, err
+	}
+	return ispnClient.Container().Members()
+}
+
+// getInfinispanConditions returns the pods status and a summary status for the cluster. The cluster view of each pod is
+// retrieved using the api.Infinispan returned by clientForPod
+func getInfinispanConditions(pods []corev1.Pod, m *infinispanv1.Infinispan, clientForPod func(podName string) (api.Infinispan, error)) []infinispanv1.InfinispanCondition {
 	var status []infinispanv1.InfinispanCondition
 	clusterViews := make(map[string]bool)
 	var errors []string
@@ -1400,7 +1551,7 @@ func getInfinispanConditions(pods []corev1.Pod, m *infinispanv1.Infinispan, curl
 	} else {
 		for _, pod := range pods {
 			if kube.IsPodReady(pod) {
-				if members, err := InfinispanForPod(pod.Name, curl).Container().Members(); err == nil {
+				if members, err := podMembers(pod.Name, clientForPod); err == nil {
 					sort.Strings(members)
 					clusterView := strings.Join(members, ",")
 					clusterViews[clusterView] = true
@@ -1485,6 +1636,10 @@ func (r *infinispanRequest) reconcileGracefulShutdown(statefulSet *appsv1.Statef
 		if err := r.update(func() {
 			ispn.SetCondition(infinispanv1.ConditionGracefulShutdown, metav1.ConditionFalse, "")
 			ispn.Status.ReplicasWantedAtRestart = 0
+			// Rebalancing remains suspended until the restarted cluster has formed
+			if ispn.Status.RebalancingSuspendedTime != nil {
+				ispn.Status.RebalancingSuspendedTime = &metav1.Time{Time: time.Now()}
+			}
 		}); err != nil {
 			return &ctrl.Result{}, err
 		}
@@ -1498,11 +1653,17 @@ func (r *infinispanRequest) reconcileGracefulShutdown(statefulSet *appsv1.Statef
 func (r *infinispanRequest) gracefulShutdownReq(podList *corev1.PodList, logger logr.Logger) (*ctrl.Result, error) {
 	ispn := r.infinispan
 	logger.Info("Sending graceful shutdown request")
+	// Disable rebalancing so that pods stopping does not trigger state transfer between the remaining pods. Rebalancing is
+	// re-enabled once the cluster has been restarted and formed
+	if err := r.suspendRebalancing(podList, "Graceful shutdown"); err != nil {
+		logger.Error(err, "Unable to disable rebalancing before graceful shutdown")
+	}
+
 	// Send a graceful shutdown to the first ready pod. If no pods are ready, then there's nothing to shutdown
 	var shutdownExecuted bool
 	for _, pod := range podList.Items {
 		if kube.IsPodReady(pod) {
-			ispnClient, err := NewInfinispanForPod(r.ctx, pod.Name, r.infinispan, r.kubernetes)
+			ispnClient, err := r.clients.NewInfinispanForPod(r.ctx, pod.Name, r.infinispan)
 			if err != nil {
 				return &ctrl.Result{}, fmt.Errorf("unable to create Infinispan client for ready pod '%s': %w", pod.Name, err)
 			}
@@ -1539,6 +1700,167 @@ func (r *infinispanRequest) gracefulShutdownReq(podList *corev1.PodList, logger
 	return &ctrl.Result{Requeue: true, RequeueAfter: time.Second}, nil
 }
 
+// reconcileScaling suspends rebalancing whilst scaling up, so that data is rebalanced once all of the new pods have joined
+// instead of after each pod. When scaling down, pods are removed one at a time so that the segments owned by each removed
+// pod are transferred to the remaining pods before the next pod is removed. Rebalancing is resumed if it has been
+// suspended for longer than DefaultRebalancingSuspendedTimeout, e.g. because the new pods are unable to join the cluster
+func (r *infinispanRequest) reconcileScaling(statefulSet *appsv1.StatefulSet, podList *corev1.PodList) (*ctrl.Result, error) {
+	ispn := r.infinispan
+	currentReplicas := *statefulSet.Spec.Replicas
+	if currentReplicas == 0 {
+		return nil, nil
+	}
+	if ispn.Spec.Replicas > currentReplicas {
+		if err := r.suspendRebalancing(podList, "Scaling up"); err != nil {
+			return &ctrl.Result{}, fmt.Errorf("unable to suspend rebalancing before scaling up: %w", err)
+		}
+		return nil, nil
+	}
+	if ispn.Spec.Replicas < currentReplicas {
+		return r.scaleDown(statefulSet, podList)
+	}
+
+	suspended := ispn.Status.RebalancingSuspendedTime
+	if suspended == nil || time.Since(suspended.Time) < consts.DefaultRebalancingSuspendedTimeout {
+		return nil, nil
+	}
+	ispnClient, err := r.readyPodInfinispan(podList)
+	if ispnClient == nil {
+		return nil, err
+	}
+	msg := fmt.Sprintf("Resuming rebalancing as it has been suspended since %s", suspended.Format(time.RFC3339))
+	r.reqLogger.Info(msg)
+	r.eventRec.Event(ispn, corev1.EventTypeWarning, EventReasonRebalancingTimeout, msg)
+	// The request is not requeued whilst the cluster is not HEALTHY, as the remaining reconciliation is still required for
+	// the cluster to form
+	if _, err := r.resumeRebalancing(ispnClient); err != nil {
+		return &ctrl.Result{}, err
+	}
+	return nil, nil
+}
+
+// scaleDown removes the pod with the highest ordinal from the StatefulSet. A ready pod is only removed once the cluster is
+// HEALTHY, i.e. once the segments owned by the previously removed pod have been transferred to the remaining pods, whereas
+// pods that are not ready do not own any segments and are removed immediately. Rebalancing is resumed first if it was
+// suspended by a scale up that has since been reverted
+func (r *infinispanRequest) scaleDown(statefulSet *appsv1.StatefulSet, podList *corev1.PodList) (*ctrl.Result, error) {
+	currentReplicas := *statefulSet.Spec.Replicas
+	if int32(len(podList.Items)) > currentReplicas {
+		r.reqLogger.Info("Waiting for the previously removed pod to terminate before scaling down")
+		return &ctrl.Result{RequeueAfter: consts.DefaultWaitClusterPodsNotReady}, nil
+	}
+
+	removedPod := fmt.Sprintf("%s-%d", statefulSet.Name, currentReplicas-1)
+	var removedPodReady bool
+	for _, pod := range podList.Items {
+		if pod.Name == removedPod {
+			removedPodReady = kube.IsPodReady(pod)
+		}
+	}
+
+	if removedPodReady {
+		ispnClient, err := r.readyPodInfinispan(podList)
+		if err != nil {
+			return &ctrl.Result{}, err
+		}
+		if r.infinispan.IsConditionTrue(infinispanv1.ConditionRebalancingSuspended) {
+			if res, err := r.resumeRebalancing(ispnClient); res != nil {
+				return res, err
+			}
+		}
+		health, err := ispnClient.Container().HealthStatus()
+		if err != nil {
+			return &ctrl.Result{}, fmt.Errorf("unable to retrieve cluster health: %w", err)
+		}
+		if health != api.HealthStatusHealth {
+			r.reqLogger.Info("Waiting for cluster to become HEALTHY before scaling down", "HealthStatus", health)
+			return &ctrl.Result{RequeueAfter: consts.DefaultWaitOnCluster}, nil
+		}
+	}
+
+	replicas := currentReplicas - 1
+	r.reqLogger.Info("Scaling down", "Pod.Name", removedPod, "replicas", replicas, "target replicas", r.infinispan.Spec.Replicas)
+	statefulSet.Spec.Replicas = &replicas
+	if err := r.Client.Update(r.ctx, statefulSet); err != nil {
+		if errors.IsConflict(err) {
+			r.reqLogger.Info("Requeuing request due to conflict on StatefulSet replicas update.")
+			return &ctrl.Result{Requeue: true}, nil
+		}
+		return &ctrl.Result{}, fmt.Errorf("unable to update StatefulSet replicas: %w", err)
+	}
+	return &ctrl.Result{RequeueAfter: consts.DefaultWaitClusterPodsNotReady}, nil
+}
+
+// readyPodInfinispan returns a client for the first ready pod, or nil if no pods are ready
+func (r *infinispanRequest) readyPodInfinispan(podList *corev1.PodList) (api.Infinispan, error) {
+	for _, pod := range podList.Items {
+		if kube.IsPodReady(pod) {
+			return r.clients.NewInfinispanForPod(r.ctx, pod.Name, r.infinispan)
+		}
+	}
+	return nil, nil
+}
+
+// suspendRebalancing disables rebalancing via the first ready pod, recording the RebalancingSuspended condition so that
+// rebalancing is resumed once the cluster has formed. Rebalancing is not suspended if no pods are ready, as there is no
+// data to rebalance
+func (r *infinispanRequest) suspendRebalancing(podList *corev1.PodList, reason string) error {
+	ispn := r.infinispan
+	// The condition remains true once rebalancing has been resumed until the cluster is HEALTHY, so the suspension time is
+	// used to determine if rebalancing is currently disabled
+	if ispn.Status.RebalancingSuspendedTime != nil {
+		return nil
+	}
+	ispnClient, err := r.readyPodInfinispan(podList)
+	if ispnClient == nil {
+		return err
+	}
+	container := ispnClient.Container()
+	if err := container.DisableRebalancing(); err != nil {
+		return err
+	}
+	r.reqLogger.Info("Rebalancing suspended", "reason", reason)
+	if err := r.update(func() {
+		ispn.SetCondition(infinispanv1.ConditionRebalancingSuspended, metav1.ConditionTrue, reason)
+		ispn.Status.RebalancingSuspendedTime = &metav1.Time{Time: time.Now()}
+	}); err != nil {
+		// Rebalancing would never be resumed without the condition
+		if enableErr := container.EnableRebalancing(); enableErr != nil {
+			r.reqLogger.Error(enableErr, "unable to enable rebalancing")
+		}
+		return err
+	}
+	return nil
+}
+
+// resumeRebalancing enables rebalancing and waits for the cluster to become HEALTHY, at which point the RebalancingSuspended
+// condition is cleared. A non-nil Result is returned whilst the cluster is not yet HEALTHY.
+func (r *infinispanRequest) resumeRebalancing(ispnClient api.Infinispan) (*ctrl.Result, error) {
+	ispn := r.infinispan
+	container := ispnClient.Container()
+	if err := container.EnableRebalancing(); err != nil {
+		return &ctrl.Result{}, fmt.Errorf("unable to enable rebalancing: %w", err)
+	}
+
+	health, err := container.HealthStatus()
+	if err != nil {
+		return &ctrl.Result{}, fmt.Errorf("unable to retrieve cluster health: %w", err)
+	}
+	if health != api.HealthStatusHealth {
+		r.reqLogger.Info("Rebalancing resumed, waiting for cluster to become HEALTHY", "HealthStatus", health)
+		return &ctrl.Result{RequeueAfter: consts.DefaultWaitOnCluster}, r.update(func() {
+			ispn.SetCondition(infinispanv1.ConditionRebalancingSuspended, metav1.ConditionTrue, fmt.Sprintf("Rebalancing resumed, cluster health is %s", health))
+			ispn.Status.RebalancingSuspendedTime = nil
+		})
+	}
+
+	r.reqLogger.Info("Rebalancing resumed and cluster is HEALTHY")
+	return nil, r.update(func() {
+		ispn.SetCondition(infinispanv1.ConditionRebalancingSuspended, metav1.ConditionFalse, "")
+		ispn.Status.RebalancingSuspendedTime = nil
+	})
+}
+
 // reconcileContainerConf reconcile the .Container struct is changed in .Spec. This needs a cluster restart
 func (r *infinispanRequest) reconcileContainerConf(statefulSet *appsv1.StatefulSet, configMap, overlayConfigMap *corev1.ConfigMap, overlayConfigMapKey string, overlayLog4jConfig bool, adminSecret,
 	userSecret, keystoreSecret, trustSecret *corev1.Secret) (*ctrl.Result, error) {
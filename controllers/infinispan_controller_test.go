@@ -0,0 +1,199 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	v1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/fake"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// testScaling returns an infinispanRequest for a cluster with the provided replicas, whose StatefulSet currently has
+// len(podsReady) replicas, each pod being ready according to podsReady
+func testScaling(t *testing.T, server *fake.Server, ispn *v1.Infinispan, podsReady ...bool) (*infinispanRequest, *appsv1.StatefulSet, *corev1.PodList) {
+	// The fake client does not set the CreationTimestamp, which is used to determine if the Infinispan CR exists
+	ispn.CreationTimestamp = metav1.Now()
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: ispn.GetStatefulSetName(), Namespace: namespace},
+		Spec:       appsv1.StatefulSetSpec{Replicas: pointer.Int32Ptr(int32(len(podsReady)))},
+	}
+	podList := &corev1.PodList{}
+	for i, ready := range podsReady {
+		pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-%d", statefulSet.Name, i), Namespace: namespace}}
+		if ready {
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		}
+		podList.Items = append(podList.Items, pod)
+	}
+
+	k8sClient, clients := testClients(t, server, ispn, statefulSet)
+	return &infinispanRequest{
+		InfinispanReconciler: &InfinispanReconciler{
+			Client:     k8sClient,
+			log:        logger,
+			scheme:     k8sClient.Scheme(),
+			kubernetes: &kube.Kubernetes{Client: k8sClient},
+			clients:    clients,
+			eventRec:   record.NewFakeRecorder(10),
+		},
+		ctx:        context.TODO(),
+		infinispan: ispn,
+		reqLogger:  logger,
+	}, statefulSet, podList
+}
+
+func statefulSetReplicas(t *testing.T, r *infinispanRequest, statefulSet *appsv1.StatefulSet) int32 {
+	updated := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(statefulSet), updated))
+	return *updated.Spec.Replicas
+}
+
+func TestScaleUpSuspendsRebalancing(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	ispn := testInfinispan()
+	ispn.Spec.Replicas = 3
+	r, statefulSet, podList := testScaling(t, server, ispn, true)
+
+	result, err := r.reconcileScaling(statefulSet, podList)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	server.Update(func(s *fake.State) {
+		assert.False(t, s.RebalancingEnabled)
+	})
+	assert.True(t, ispn.IsConditionTrue(v1.ConditionRebalancingSuspended))
+	assert.NotNil(t, ispn.Status.RebalancingSuspendedTime)
+
+	// Rebalancing is resumed once the scaled cluster is HEALTHY
+	result, err = r.resumeRebalancing(server.Infinispan())
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	server.Update(func(s *fake.State) {
+		assert.True(t, s.RebalancingEnabled)
+	})
+	assert.False(t, ispn.IsConditionTrue(v1.ConditionRebalancingSuspended))
+	assert.Nil(t, ispn.Status.RebalancingSuspendedTime)
+}
+
+func TestRebalancingSuspendedTimeout(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	server.Update(func(s *fake.State) {
+		s.RebalancingEnabled = false
+		s.Health = api.HealthStatusDegraded
+	})
+
+	ispn := testInfinispan()
+	ispn.Spec.Replicas = 2
+	ispn.SetCondition(v1.ConditionRebalancingSuspended, metav1.ConditionTrue, "Scaling up")
+	ispn.Status.RebalancingSuspendedTime = &metav1.Time{Time: time.Now().Add(-consts.DefaultRebalancingSuspendedTimeout / 2)}
+	r, statefulSet, podList := testScaling(t, server, ispn, true, false)
+
+	result, err := r.reconcileScaling(statefulSet, podList)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	server.Update(func(s *fake.State) {
+		assert.False(t, s.RebalancingEnabled)
+	})
+
+	ispn.Status.RebalancingSuspendedTime = &metav1.Time{Time: time.Now().Add(-2 * consts.DefaultRebalancingSuspendedTimeout)}
+	result, err = r.reconcileScaling(statefulSet, podList)
+	assert.NoError(t, err)
+	// The remaining reconciliation is not blocked whilst the cluster is not HEALTHY
+	assert.Nil(t, result)
+	server.Update(func(s *fake.State) {
+		assert.True(t, s.RebalancingEnabled)
+	})
+	assert.Nil(t, ispn.Status.RebalancingSuspendedTime)
+	assert.Len(t, r.eventRec.(*record.FakeRecorder).Events, 1)
+}
+
+func TestScaleDownWaitsForHealthyCluster(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	server.Update(func(s *fake.State) {
+		s.Health = api.HealthStatusDegraded
+	})
+
+	ispn := testInfinispan()
+	ispn.Spec.Replicas = 1
+	r, statefulSet, podList := testScaling(t, server, ispn, true, true, true)
+
+	result, err := r.reconcileScaling(statefulSet, podList)
+	assert.NoError(t, err)
+	assert.Equal(t, &ctrl.Result{RequeueAfter: consts.DefaultWaitOnCluster}, result)
+	assert.Equal(t, int32(3), statefulSetReplicas(t, r, statefulSet))
+
+	// A single pod is removed once the cluster is HEALTHY
+	server.Update(func(s *fake.State) {
+		s.Health = api.HealthStatusHealth
+	})
+	result, err = r.reconcileScaling(statefulSet, podList)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, int32(2), statefulSetReplicas(t, r, statefulSet))
+
+	// The next pod is not removed until the previously removed pod has terminated
+	result, err = r.reconcileScaling(statefulSet, podList)
+	assert.NoError(t, err)
+	assert.Equal(t, &ctrl.Result{RequeueAfter: consts.DefaultWaitClusterPodsNotReady}, result)
+	assert.Equal(t, int32(2), statefulSetReplicas(t, r, statefulSet))
+}
+
+func TestScaleDownRemovesPodsThatAreNotReady(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	server.Update(func(s *fake.State) {
+		s.Health = api.HealthStatusDegraded
+		s.RebalancingEnabled = false
+	})
+
+	// A reverted scale up whose new pod never became ready
+	ispn := testInfinispan()
+	ispn.Spec.Replicas = 1
+	ispn.SetCondition(v1.ConditionRebalancingSuspended, metav1.ConditionTrue, "Scaling up")
+	ispn.Status.RebalancingSuspendedTime = &metav1.Time{Time: time.Now()}
+	r, statefulSet, podList := testScaling(t, server, ispn, true, false)
+
+	result, err := r.reconcileScaling(statefulSet, podList)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, int32(1), statefulSetReplicas(t, r, statefulSet))
+}
+
+func TestScaleDownResumesRebalancing(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	server.Update(func(s *fake.State) {
+		s.RebalancingEnabled = false
+	})
+
+	ispn := testInfinispan()
+	ispn.Spec.Replicas = 1
+	ispn.SetCondition(v1.ConditionRebalancingSuspended, metav1.ConditionTrue, "Scaling up")
+	ispn.Status.RebalancingSuspendedTime = &metav1.Time{Time: time.Now()}
+	r, statefulSet, podList := testScaling(t, server, ispn, true, true)
+
+	result, err := r.reconcileScaling(statefulSet, podList)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	server.Update(func(s *fake.State) {
+		assert.True(t, s.RebalancingEnabled)
+	})
+	assert.False(t, ispn.IsConditionTrue(v1.ConditionRebalancingSuspended))
+	assert.Equal(t, int32(1), statefulSetReplicas(t, r, statefulSet))
+}
@@ -3,9 +3,12 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	v1 "github.com/infinispan/infinispan-operator/api/v1"
 	"github.com/infinispan/infinispan-operator/api/v2alpha1"
 	"github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/encryption"
 	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
 	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
 	corev1 "k8s.io/api/core/v1"
@@ -20,6 +23,7 @@ import (
 )
 
 // +kubebuilder:rbac:groups=infinispan.org,namespace=infinispan-operator-system,resources=restores;restores/status;restores/finalizers,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=core,resources=persistentvolumes,verbs=get
 
 // RestoreReconciler reconciles a Restore object
 type RestoreReconciler struct {
@@ -29,6 +33,7 @@ type RestoreReconciler struct {
 type restore struct {
 	instance *v2alpha1.Restore
 	client   client.Client
+	kube     *kube.Kubernetes
 	scheme   *runtime.Scheme
 	ctx      context.Context
 }
@@ -47,6 +52,7 @@ func (r *RestoreReconciler) ResourceInstance(ctx context.Context, name types.Nam
 	restore := &restore{
 		instance: instance,
 		client:   r.Client,
+		kube:     ctrl.Kube,
 		scheme:   ctrl.Scheme,
 		ctx:      ctx,
 	}
@@ -57,7 +63,7 @@ func (r *RestoreReconciler) Type() client.Object {
 	return &v2alpha1.Restore{}
 }
 
-func (r *restore) AsMeta() metav1.Object {
+func (r *restore) AsMeta() client.Object {
 	return r.instance
 }
 
@@ -118,6 +124,49 @@ func (r *restore) update(mutate func()) (bool, error) {
 }
 
 func (r *restore) Init() (*zeroCapacitySpec, error) {
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+
+	if r.instance.S3() != nil {
+		// The archive is downloaded to the pod before the restore is executed, so the Backup CR is not required
+		return &zeroCapacitySpec{
+			Container: r.instance.Spec.Container,
+			PodLabels: RestorePodLabels(r.instance.Name, r.instance.Spec.Cluster),
+			Volume: zeroCapacityVolumeSpec{
+				MountPath: BackupDataMountPath,
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				},
+			},
+		}, nil
+	}
+
+	if volume := r.instance.Volume(); volume != nil {
+		// The archive is read from a user provided volume, so the Backup CR may exist in another namespace or cluster
+		claimName := volume.ClaimName
+		if volume.VolumeName != "" {
+			var err error
+			if claimName, err = r.ensureSourceClaim(volume.VolumeName); err != nil {
+				return nil, err
+			}
+		}
+		return &zeroCapacitySpec{
+			Container: r.instance.Spec.Container,
+			PodLabels: RestorePodLabels(r.instance.Name, r.instance.Spec.Cluster),
+			Volume: zeroCapacityVolumeSpec{
+				MountPath: BackupDataMountPath,
+				ReadOnly:  true,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: claimName,
+						ReadOnly:  true,
+					},
+				},
+			},
+		}, nil
+	}
+
 	backup := &v2alpha1.Backup{}
 	backupKey := types.NamespacedName{
 		Namespace: r.instance.Namespace,
@@ -143,6 +192,339 @@ func (r *restore) Init() (*zeroCapacitySpec, error) {
 	}, nil
 }
 
+// ensureSourceClaim creates a PersistentVolumeClaim in the Restore's namespace that is bound to the named
+// PersistentVolume, returning the name of the claim. The claim is owned by the Restore, so that the volume is released
+// once the Restore is deleted
+func (r *restore) ensureSourceClaim(volumeName string) (string, error) {
+	restore := r.instance
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      restore.Name + "-source",
+			Namespace: restore.Namespace,
+		},
+	}
+	if err := r.client.Get(r.ctx, client.ObjectKeyFromObject(pvc), pvc); err == nil {
+		return pvc.Name, nil
+	} else if !errors.IsNotFound(err) {
+		return "", err
+	}
+
+	pv := &corev1.PersistentVolume{}
+	if err := r.client.Get(r.ctx, types.NamespacedName{Name: volumeName}, pv); err != nil {
+		return "", fmt.Errorf("unable to load PersistentVolume '%s': %w", volumeName, err)
+	}
+	// The storage class, capacity and access modes must match the volume for the claim to be bound to it
+	pvc.Spec = corev1.PersistentVolumeClaimSpec{
+		AccessModes:      pv.Spec.AccessModes,
+		StorageClassName: &pv.Spec.StorageClassName,
+		VolumeMode:       pv.Spec.VolumeMode,
+		VolumeName:       pv.Name,
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceStorage: pv.Spec.Capacity[corev1.ResourceStorage]},
+		},
+	}
+	if err := controllerutil.SetControllerReference(restore, pvc, r.scheme); err != nil {
+		return "", err
+	}
+	if err := r.client.Create(r.ctx, pvc); err != nil {
+		return "", fmt.Errorf("unable to create PersistentVolumeClaim for PersistentVolume '%s': %w", volumeName, err)
+	}
+	return pvc.Name, nil
+}
+
+// Bootstrap recreates the Kubernetes resources stored by the Backup if the Infinispan cluster does not exist
+func (r *restore) Bootstrap() (bool, error) {
+	restore := r.instance
+	// Validate before any resources are created, so that an invalid spec fails the Restore
+	if err := r.validate(); err != nil {
+		return false, err
+	}
+	if !restore.Spec.IncludeKubernetesResources {
+		return true, nil
+	}
+
+	err := r.client.Get(r.ctx, types.NamespacedName{Namespace: restore.Namespace, Name: restore.Spec.Cluster}, &v1.Infinispan{})
+	if err == nil {
+		// The cluster already exists, or has been recreated by a previous reconciliation
+		return true, nil
+	} else if !errors.IsNotFound(err) {
+		return false, err
+	}
+
+	content, err := r.readKubernetesResources()
+	if err != nil || content == nil {
+		return false, err
+	}
+
+	var key []byte
+	if encryptionSpec := restore.Spec.Encryption; encryptionSpec != nil {
+		if key, err = encryptionKey(r.ctx, r.client, restore.Namespace, encryptionSpec); err != nil {
+			return false, err
+		}
+	}
+	resources, err := parseKubernetesResources(content, key)
+	if err != nil {
+		return false, err
+	}
+	resources.rewrite(restore.Namespace, restore.Spec.Cluster)
+	if err := resources.create(r.ctx, r.client); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// readKubernetesResources returns the Kubernetes resources stored alongside the archive, or nil if they cannot be read yet.
+// Volumes can only be read via a pod, so a short-lived reader pod is created as the zero-capacity pod requires the cluster
+func (r *restore) readKubernetesResources() ([]byte, error) {
+	restore := r.instance
+	if s3Spec := restore.S3(); s3Spec != nil {
+		content, err := getS3Object(r.ctx, r.client, restore.Namespace, s3Spec, resourcesPath(s3Spec.ObjectKey(restore.Spec.Backup)))
+		if err != nil {
+			return nil, err
+		}
+		if content == nil {
+			return nil, fmt.Errorf("backup '%s' does not include Kubernetes resources", restore.Spec.Backup)
+		}
+		return content, nil
+	}
+
+	spec, err := r.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      restore.Name + "-resources",
+			Namespace: restore.Namespace,
+		},
+	}
+	if err := r.client.Get(r.ctx, client.ObjectKeyFromObject(pod), pod); err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+		pod.Labels = map[string]string{"restore_cr": restore.Name}
+		pod.Spec = corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{{
+				Name:  "resources",
+				Image: constants.InitContainerImageName,
+				// The pod is deleted once the resources have been read, the timeout ensures that it terminates otherwise
+				Command: []string{"sleep", "600"},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "backup",
+					MountPath: BackupDataMountPath,
+					ReadOnly:  true,
+				}},
+			}},
+			Volumes: []corev1.Volume{{
+				Name:         "backup",
+				VolumeSource: spec.Volume.VolumeSource,
+			}},
+		}
+		if err := controllerutil.SetControllerReference(restore, pod, r.scheme); err != nil {
+			return nil, err
+		}
+		if err := r.client.Create(r.ctx, pod); err != nil {
+			return nil, fmt.Errorf("unable to create Kubernetes resources reader pod: %w", err)
+		}
+		return nil, nil
+	}
+
+	if pod.Status.Phase != corev1.PodRunning {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			// Recreate the pod on the next reconciliation
+			return nil, r.client.Delete(r.ctx, pod)
+		}
+		return nil, nil
+	}
+
+	path := resourcesPath(r.archivePath())
+	stdout, err := r.kube.ExecWithOptions(kube.ExecOptions{
+		Container: "resources",
+		Command:   []string{"sh", "-c", `if [ -f "$0" ]; then cat "$0"; fi`, path},
+		Namespace: pod.Namespace,
+		PodName:   pod.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read Kubernetes resources '%s': %w", path, err)
+	}
+	if err := r.client.Delete(r.ctx, pod); err != nil && !errors.IsNotFound(err) {
+		return nil, fmt.Errorf("unable to delete Kubernetes resources reader pod: %w", err)
+	}
+	if stdout.Len() == 0 {
+		return nil, fmt.Errorf("backup archive '%s' does not include Kubernetes resources", r.archivePath())
+	}
+	return stdout.Bytes(), nil
+}
+
+// validate ensures that the location of the backup archive can be determined from the spec
+func (r *restore) validate() error {
+	spec := r.instance.Spec
+	volume := r.instance.Volume()
+	if r.instance.S3() != nil && volume != nil {
+		return fmt.Errorf("only one of 'spec.source.s3' or 'spec.source.volume' can be defined")
+	}
+	if spec.Backup == "" && (volume == nil || volume.Path == "") {
+		return fmt.Errorf("'spec.backup' must be defined unless 'spec.source.volume.path' is defined")
+	}
+	if volume != nil {
+		if (volume.ClaimName == "") == (volume.VolumeName == "") {
+			return fmt.Errorf("exactly one of 'spec.source.volume.claimName' or 'spec.source.volume.volumeName' must be defined")
+		}
+		for _, element := range strings.Split(volume.Path, "/") {
+			if element == ".." {
+				return fmt.Errorf("'spec.source.volume.path' must not contain '..'")
+			}
+		}
+	}
+	return nil
+}
+
+// archivePath returns the path of the backup archive on the zero-capacity pod
+func (r *restore) archivePath() string {
+	return fmt.Sprintf("%s/%s", BackupDataMountPath, r.instance.ArchivePath())
+}
+
+// location returns the path of the archive passed to the server, which is the decrypted copy if the archive is encrypted
+func (r *restore) location() string {
+	if r.instance.Spec.Encryption != nil {
+		return fmt.Sprintf("%s/%s.zip", DataMountPath, r.instance.Name)
+	}
+	return r.archivePath()
+}
+
+func (r *restore) PreExec(client api.Infinispan) error {
+	restore := r.instance
+	var key []byte
+	if encryptionSpec := restore.Spec.Encryption; encryptionSpec != nil {
+		var err error
+		if key, err = encryptionKey(r.ctx, r.client, restore.Namespace, encryptionSpec); err != nil {
+			return err
+		}
+	}
+
+	// Compare the key with the one recorded by the Backup so that the wrong key is detected before any content is retrieved
+	if err := r.verifyBackupEncryption(key); err != nil {
+		return err
+	}
+
+	pod := types.NamespacedName{Namespace: restore.Namespace, Name: restore.Name}
+	archivePath := r.archivePath()
+	var manifest *backupManifest
+	if s3Spec := restore.S3(); s3Spec != nil {
+		// Verify the manifest before downloading the archive so that incompatible backups are rejected immediately
+		objectKey := s3Spec.ObjectKey(restore.Spec.Backup)
+		content, err := getS3Object(r.ctx, r.client, restore.Namespace, s3Spec, manifestPath(objectKey))
+		if err != nil {
+			return err
+		}
+		if content != nil {
+			if manifest, err = parseManifest(content); err != nil {
+				return err
+			}
+			if err := r.verifyManifest(client, manifest); err != nil {
+				return err
+			}
+		}
+		if err := downloadFromS3(r.ctx, r.client, r.kube, pod, archivePath, s3Spec, objectKey); err != nil {
+			return err
+		}
+	}
+
+	// Ensure that the archive is available before initiating the restore, as the server only reports a generic failure
+	_, err := r.kube.ExecWithOptions(kube.ExecOptions{
+		Container: InfinispanContainer,
+		Command:   []string{"sh", "-c", `test -f "$0" && test -r "$0"`, archivePath},
+		Namespace: pod.Namespace,
+		PodName:   pod.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("backup archive '%s' does not exist or is not readable", archivePath)
+	}
+
+	if restore.S3() == nil {
+		if manifest, err = readManifest(r.kube, pod, archivePath); err != nil {
+			return err
+		}
+		if manifest != nil {
+			if err := r.verifyManifest(client, manifest); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Archives created before manifests were introduced cannot be verified
+	if manifest != nil {
+		size, digest, err := archiveDigest(r.kube, pod, archivePath)
+		if err != nil {
+			return err
+		}
+		if err := manifest.verifyArchive(size, digest); err != nil {
+			return err
+		}
+	}
+
+	header, err := archiveHeader(r.kube, pod, archivePath)
+	if err != nil {
+		return err
+	}
+	if header == nil {
+		if key != nil {
+			return fmt.Errorf("'spec.encryption' is defined but backup archive '%s' is not encrypted", archivePath)
+		}
+		return nil
+	}
+	if key == nil {
+		return fmt.Errorf("backup archive '%s' is encrypted, 'spec.encryption' must be defined", archivePath)
+	}
+	if keyID := encryption.KeyID(key); keyID != header.KeyID {
+		return fmt.Errorf("backup archive '%s' was encrypted with key id '%s', but the key in Secret '%s' has id '%s'", archivePath, header.KeyID, restore.Spec.Encryption.SecretName, keyID)
+	}
+	return decryptArchive(r.kube, pod, archivePath, r.location(), key)
+}
+
+// verifyManifest checks that the archive described by the manifest can be restored by the server and contains all of
+// the requested resources
+func (r *restore) verifyManifest(client api.Infinispan, manifest *backupManifest) error {
+	info, err := client.Server().Info()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve server version: %w", err)
+	}
+	if err := manifest.verifyServerVersion(info.Version); err != nil {
+		return err
+	}
+	return manifest.verifyResources(r.instance.Spec.Resources)
+}
+
+// verifyBackupEncryption checks that the key matches the encryption recorded in the status of the Backup, if the
+// Backup CR exists
+func (r *restore) verifyBackupEncryption(key []byte) error {
+	restore := r.instance
+	if restore.Spec.Backup == "" || restore.Spec.Source != nil {
+		return nil
+	}
+
+	backup := &v2alpha1.Backup{}
+	if err := r.client.Get(r.ctx, types.NamespacedName{Namespace: restore.Namespace, Name: restore.Spec.Backup}, backup); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("unable to load Infinispan Backup '%s': %w", restore.Spec.Backup, err)
+	}
+
+	status := backup.Status.Encryption
+	switch {
+	case status == nil && key != nil:
+		return fmt.Errorf("'spec.encryption' is defined but Backup '%s' is not encrypted", backup.Name)
+	case status != nil && key == nil:
+		return fmt.Errorf("backup '%s' is encrypted with %s, 'spec.encryption' must be defined", backup.Name, status.Algorithm)
+	case status != nil && status.KeyID != encryption.KeyID(key):
+		return fmt.Errorf("backup '%s' was encrypted with key id '%s', but the key in Secret '%s' has id '%s'", backup.Name, status.KeyID, restore.Spec.Encryption.SecretName, encryption.KeyID(key))
+	}
+	return nil
+}
+
 func (r *restore) Exec(client api.Infinispan) error {
 	instance := r.instance
 	var resources api.BackupRestoreResources
@@ -158,7 +540,7 @@ func (r *restore) Exec(client api.Infinispan) error {
 		}
 	}
 	config := &api.RestoreConfig{
-		Location:  fmt.Sprintf("%[1]s/%[2]s/%[2]s.zip", BackupDataMountPath, instance.Spec.Backup),
+		Location:  r.location(),
 		Resources: resources,
 	}
 	return client.Container().Restores().Create(instance.Name, config)
@@ -173,3 +555,48 @@ func (r *restore) ExecStatus(client api.Infinispan) (zeroCapacityPhase, error) {
 	}
 	return zeroCapacityPhase(status), nil
 }
+
+func (r *restore) Cancel(client api.Infinispan) (bool, error) {
+	name := r.instance.Name
+	restores := client.Container().Restores()
+	if err := restores.Cancel(name); err != nil {
+		return false, err
+	}
+
+	// The restore no longer exists on the server once it has stopped, so Status returns an error
+	if status, err := restores.Status(name); err == nil && status == api.StatusRunning {
+		return false, nil
+	}
+
+	// Remove any archive copies created by the operator, the source archive is never modified
+	var paths []string
+	if r.instance.S3() != nil {
+		paths = append(paths, r.archivePath())
+	}
+	if r.instance.Spec.Encryption != nil {
+		paths = append(paths, r.location())
+	}
+	if len(paths) == 0 {
+		return true, nil
+	}
+
+	_, err := r.kube.ExecWithOptions(kube.ExecOptions{
+		Container: InfinispanContainer,
+		Command:   append([]string{"rm", "-f"}, paths...),
+		Namespace: r.instance.Namespace,
+		PodName:   name,
+	})
+	if err != nil {
+		return false, fmt.Errorf("unable to remove partial restore archive: %w", err)
+	}
+	return true, nil
+}
+
+func (r *restore) PostExec(client api.Infinispan) error {
+	return nil
+}
+
+// Finalize is a no-op as the source archive is never modified
+func (r *restore) Finalize() error {
+	return nil
+}
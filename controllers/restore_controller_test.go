@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func testVolumeRestore(volume *v2alpha1.RestoreVolumeSourceSpec) *v2alpha1.Restore {
+	return &v2alpha1.Restore{
+		ObjectMeta: testObjectMeta("restore"),
+		Spec: v2alpha1.RestoreSpec{
+			Cluster: "example",
+			Backup:  "backup",
+			Source:  &v2alpha1.RestoreSourceSpec{Volume: volume},
+		},
+	}
+}
+
+func TestRestoreValidateVolume(t *testing.T) {
+	validate := func(volume *v2alpha1.RestoreVolumeSourceSpec) error {
+		return (&restore{instance: testVolumeRestore(volume)}).validate()
+	}
+	assert.NoError(t, validate(&v2alpha1.RestoreVolumeSourceSpec{ClaimName: "pvc", Path: "backups/backup.zip"}))
+	assert.NoError(t, validate(&v2alpha1.RestoreVolumeSourceSpec{VolumeName: "pv", Path: "/backup..zip"}))
+	assert.EqualError(t, validate(&v2alpha1.RestoreVolumeSourceSpec{}),
+		"exactly one of 'spec.source.volume.claimName' or 'spec.source.volume.volumeName' must be defined")
+	assert.EqualError(t, validate(&v2alpha1.RestoreVolumeSourceSpec{ClaimName: "pvc", VolumeName: "pv"}),
+		"exactly one of 'spec.source.volume.claimName' or 'spec.source.volume.volumeName' must be defined")
+	assert.EqualError(t, validate(&v2alpha1.RestoreVolumeSourceSpec{ClaimName: "pvc", Path: "../other/backup.zip"}),
+		"'spec.source.volume.path' must not contain '..'")
+	assert.EqualError(t, validate(&v2alpha1.RestoreVolumeSourceSpec{ClaimName: "pvc", Path: "backups/../../backup.zip"}),
+		"'spec.source.volume.path' must not contain '..'")
+}
+
+func TestRestoreInitVolume(t *testing.T) {
+	instance := testVolumeRestore(&v2alpha1.RestoreVolumeSourceSpec{ClaimName: "pvc"})
+	k8sClient, _ := testClients(t, nil, instance)
+	r := &restore{instance: instance, client: k8sClient, scheme: k8sClient.Scheme(), ctx: context.TODO()}
+
+	spec, err := r.Init()
+	assert.NoError(t, err)
+	assert.True(t, spec.Volume.ReadOnly)
+	assert.Equal(t, &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc", ReadOnly: true}, spec.Volume.VolumeSource.PersistentVolumeClaim)
+
+	// A claim bound to the PersistentVolume is created in the Restore's namespace
+	storageClass := "standard"
+	pv := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pv"},
+		Spec: corev1.PersistentVolumeSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Capacity:         corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			StorageClassName: storageClass,
+		},
+	}
+	instance = testVolumeRestore(&v2alpha1.RestoreVolumeSourceSpec{VolumeName: "pv"})
+	k8sClient, _ = testClients(t, nil, instance, pv)
+	r = &restore{instance: instance, client: k8sClient, scheme: k8sClient.Scheme(), ctx: context.TODO()}
+
+	spec, err = r.Init()
+	assert.NoError(t, err)
+	assert.Equal(t, "restore-source", spec.Volume.VolumeSource.PersistentVolumeClaim.ClaimName)
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	assert.NoError(t, k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: "restore-source"}, pvc))
+	assert.Equal(t, "pv", pvc.Spec.VolumeName)
+	assert.Equal(t, &storageClass, pvc.Spec.StorageClassName)
+	assert.Equal(t, pv.Spec.AccessModes, pvc.Spec.AccessModes)
+	assert.True(t, resource.MustParse("1Gi").Equal(pvc.Spec.Resources.Requests[corev1.ResourceStorage]))
+	assert.True(t, metav1.IsControlledBy(pvc, instance))
+
+	// The existing claim is reused
+	spec, err = r.Init()
+	assert.NoError(t, err)
+	assert.Equal(t, "restore-source", spec.Volume.VolumeSource.PersistentVolumeClaim.ClaimName)
+
+	instance = testVolumeRestore(&v2alpha1.RestoreVolumeSourceSpec{VolumeName: "missing"})
+	instance.Name = "other"
+	r = &restore{instance: instance, client: k8sClient, scheme: k8sClient.Scheme(), ctx: context.TODO()}
+	_, err = r.Init()
+	assert.Error(t, err)
+}
@@ -0,0 +1,183 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2alpha1 "github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// SchemaReconciler reconciles a Schema object
+type SchemaReconciler struct {
+	client.Client
+	log        logr.Logger
+	scheme     *runtime.Scheme
+	kubernetes *kube.Kubernetes
+	clients    *InfinispanClientFactory
+	eventRec   record.EventRecorder
+}
+
+type schemaRequest struct {
+	*SchemaReconciler
+	*clusterResource
+	schema     *v2alpha1.Schema
+	ispnClient api.Infinispan
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SchemaReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	r.Client = mgr.GetClient()
+	r.log = ctrl.Log.WithName("controllers").WithName("Schema")
+	r.scheme = mgr.GetScheme()
+	r.kubernetes = kube.NewKubernetesFromController(mgr)
+	r.clients = NewInfinispanClientFactory(r.kubernetes)
+	r.eventRec = mgr.GetEventRecorderFor("schema-controller")
+
+	newList := func() client.ObjectList { return &v2alpha1.SchemaList{} }
+	builder := ctrl.NewControllerManagedBy(mgr).For(&v2alpha1.Schema{})
+	err := watchClusterResources(ctx, mgr, builder, r.kubernetes, r.log, &v2alpha1.Schema{}, newList,
+		func(obj client.Object) string { return obj.(*v2alpha1.Schema).Spec.ClusterName },
+	)
+	if err != nil {
+		return err
+	}
+	err = watchConfigMapKeyRefs(ctx, mgr, builder, r.kubernetes, r.log, &v2alpha1.Schema{}, newList,
+		func(obj client.Object) string {
+			if ref := obj.(*v2alpha1.Schema).Spec.ConfigMapKeyRef; ref != nil {
+				return ref.Name
+			}
+			return ""
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return builder.Complete(r)
+}
+
+// +kubebuilder:rbac:groups=infinispan.org,namespace=infinispan-operator-system,resources=schemas;schemas/status;schemas/finalizers,verbs=get;list;watch;create;update;patch;delete
+
+func (r *SchemaReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	reqLogger := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("+++++ Reconciling Schema.")
+	defer reqLogger.Info("----- End Reconciling Schema.")
+
+	// Fetch the Schema instance
+	instance := &v2alpha1.Schema{}
+	if err := r.Client.Get(ctx, request.NamespacedName, instance); err != nil {
+		if errors.IsNotFound(err) {
+			reqLogger.Info("Schema resource not found. Ignoring it since the object must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	schema := &schemaRequest{
+		SchemaReconciler: r,
+		clusterResource: &clusterResource{
+			client:    r.Client,
+			ctx:       ctx,
+			object:    instance,
+			reqLogger: reqLogger,
+			kind:      "schema",
+			setReady: func(status metav1.ConditionStatus, message string) {
+				instance.SetCondition(v2alpha1.SchemaConditionReady, status, message)
+			},
+		},
+		schema: instance,
+	}
+
+	ispnClient, err := schema.infinispanClient(instance.Spec.ClusterName, r.clients)
+	if ispnClient == nil {
+		return ctrl.Result{}, err
+	}
+	schema.ispnClient = ispnClient
+
+	if instance.GetDeletionTimestamp() != nil {
+		// Remove Deleted schemas from the server before removing the Finalizer
+		return ctrl.Result{}, schema.finalize(func() error {
+			return ispnClient.Schemas().Delete(instance.GetSchemaName())
+		})
+	}
+
+	validationError, err := schema.ispnCreateOrUpdate()
+	if err != nil {
+		reqLogger.Error(err, "Unable to reconcile Schema")
+		// The ConfigMap watch ensures that a request is queued once the ConfigMap is created
+		return ctrl.Result{Requeue: !errors.IsNotFound(err)}, schema.updateReady(metav1.ConditionFalse, err.Error(), false)
+	}
+
+	return ctrl.Result{}, schema.update(func() error {
+		if validationError != nil {
+			instance.Status.ValidationError = &v2alpha1.SchemaValidationError{
+				Message: validationError.Message,
+				Cause:   validationError.Cause,
+			}
+			schema.setReady(metav1.ConditionFalse, fmt.Sprintf("schema validation failed: %s", validationError.Message))
+		} else {
+			instance.Status.ValidationError = nil
+			schema.setReady(metav1.ConditionTrue, "")
+		}
+		// Add finalizer so that the Schema is removed on the server when the Schema CR is deleted
+		controllerutil.AddFinalizer(instance, constants.InfinispanFinalizer)
+		return nil
+	})
+}
+
+// content returns the Protobuf schema defined inline or in the referenced ConfigMap
+func (r *schemaRequest) content() (string, error) {
+	spec := r.schema.Spec
+	if spec.Schema != "" {
+		return spec.Schema, nil
+	}
+
+	if spec.ConfigMapKeyRef == nil {
+		return "", fmt.Errorf("one of 'spec.schema' or 'spec.configMapKeyRef' must be defined")
+	}
+	return r.configMapValue(spec.ConfigMapKeyRef)
+}
+
+// ispnCreateOrUpdate registers the schema on the server if it doesn't exist, or updates it if the content has changed.
+// The validation error reported by the server is returned if the schema is invalid.
+func (r *schemaRequest) ispnCreateOrUpdate() (*api.SchemaError, error) {
+	name := r.schema.GetSchemaName()
+	schemas := r.ispnClient.Schemas()
+
+	content, err := r.content()
+	if err != nil {
+		return nil, err
+	}
+
+	existing, exists, err := schemas.Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve schema: %w", err)
+	}
+
+	if !exists {
+		if err := schemas.Register(name, content); err != nil {
+			return nil, fmt.Errorf("unable to register schema: %w", err)
+		}
+	} else if strings.TrimSpace(existing) != strings.TrimSpace(content) {
+		if err := schemas.Update(name, content); err != nil {
+			return nil, fmt.Errorf("unable to update schema: %w", err)
+		}
+	}
+
+	validationError, err := schemas.Errors(name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve schema validation errors: %w", err)
+	}
+	return validationError, nil
+}
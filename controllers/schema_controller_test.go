@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/fake"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// testProtoSchema contains quotes, which must be registered verbatim
+const testProtoSchema = "// don't rename the \"Person\" message\nmessage Person { optional string name = 1; }"
+
+func testSchema(spec v2alpha1.SchemaSpec) *v2alpha1.Schema {
+	spec.ClusterName = "example"
+	return &v2alpha1.Schema{
+		ObjectMeta: testObjectMeta("person"),
+		Spec:       spec,
+	}
+}
+
+func TestSchemaReconcilerRegistersConfigMapSchema(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "schemas", Namespace: namespace},
+		Data:       map[string]string{"person.proto": testProtoSchema},
+	}
+	schema := testSchema(v2alpha1.SchemaSpec{
+		ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "schemas"},
+			Key:                  "person.proto",
+		},
+	})
+	k8sClient, clients := testClients(t, server, testInfinispan(), configMap, schema)
+	r := SchemaReconciler(newTestReconciler(k8sClient, clients))
+
+	request := testRequest(schema)
+	_, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+
+	server.Update(func(s *fake.State) {
+		assert.Contains(t, s.Schemas, "person.proto")
+		assert.Equal(t, testProtoSchema, s.Schemas["person.proto"].Content)
+	})
+
+	updated := &v2alpha1.Schema{}
+	assert.NoError(t, k8sClient.Get(context.TODO(), request.NamespacedName, updated))
+	assert.Contains(t, updated.Finalizers, constants.InfinispanFinalizer)
+	assert.Nil(t, updated.Status.ValidationError)
+	assert.Equal(t, []v2alpha1.SchemaCondition{{Type: v2alpha1.SchemaConditionReady, Status: metav1.ConditionTrue}}, updated.Status.Conditions)
+}
+
+func TestSchemaReconcilerConfigMapNotFound(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	schema := testSchema(v2alpha1.SchemaSpec{
+		ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "schemas"},
+			Key:                  "person.proto",
+		},
+	})
+	k8sClient, clients := testClients(t, server, testInfinispan(), schema)
+	r := SchemaReconciler(newTestReconciler(k8sClient, clients))
+
+	request := testRequest(schema)
+	result, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	// The ConfigMap watch queues a request once the ConfigMap is created
+	assert.False(t, result.Requeue)
+
+	updated := &v2alpha1.Schema{}
+	assert.NoError(t, k8sClient.Get(context.TODO(), request.NamespacedName, updated))
+	assert.NotContains(t, updated.Finalizers, constants.InfinispanFinalizer)
+	assert.Equal(t, metav1.ConditionFalse, updated.Status.Conditions[0].Status)
+}
+
+func TestSchemaReconcilerValidationError(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	server.Update(func(s *fake.State) {
+		s.Schemas["person.proto"] = &fake.Schema{
+			Content: testProtoSchema,
+			Error:   &api.SchemaError{Message: "Syntax error", Cause: "unexpected token"},
+		}
+	})
+
+	schema := testSchema(v2alpha1.SchemaSpec{Schema: testProtoSchema})
+	schema.Status.ValidationError = &v2alpha1.SchemaValidationError{Message: "outdated"}
+	k8sClient, clients := testClients(t, server, testInfinispan(), schema)
+	r := SchemaReconciler(newTestReconciler(k8sClient, clients))
+
+	request := testRequest(schema)
+	_, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+
+	updated := &v2alpha1.Schema{}
+	assert.NoError(t, k8sClient.Get(context.TODO(), request.NamespacedName, updated))
+	assert.Contains(t, updated.Finalizers, constants.InfinispanFinalizer)
+	assert.Equal(t, &v2alpha1.SchemaValidationError{Message: "Syntax error", Cause: "unexpected token"}, updated.Status.ValidationError)
+	assert.Equal(t, metav1.ConditionFalse, updated.Status.Conditions[0].Status)
+	assert.Equal(t, "schema validation failed: Syntax error", updated.Status.Conditions[0].Message)
+}
+
+func TestSchemaReconcilerDeletesSchema(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	server.Update(func(s *fake.State) {
+		s.Schemas["person.proto"] = &fake.Schema{Content: testProtoSchema}
+	})
+
+	now := metav1.Now()
+	schema := testSchema(v2alpha1.SchemaSpec{Schema: testProtoSchema})
+	schema.DeletionTimestamp = &now
+	schema.Finalizers = []string{constants.InfinispanFinalizer}
+	k8sClient, clients := testClients(t, server, testInfinispan(), schema)
+	r := SchemaReconciler(newTestReconciler(k8sClient, clients))
+
+	request := testRequest(schema)
+	_, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+
+	server.Update(func(s *fake.State) {
+		assert.NotContains(t, s.Schemas, "person.proto")
+	})
+	updated := &v2alpha1.Schema{}
+	assert.NoError(t, k8sClient.Get(context.TODO(), request.NamespacedName, updated))
+	assert.NotContains(t, updated.Finalizers, constants.InfinispanFinalizer)
+}
@@ -0,0 +1,252 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-logr/logr"
+	v2alpha1 "github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The maximum number of characters of a task result stored in the Task status
+const maxTaskResultLength = 4096
+
+// TaskReconciler reconciles a Task object
+type TaskReconciler struct {
+	client.Client
+	log        logr.Logger
+	scheme     *runtime.Scheme
+	kubernetes *kube.Kubernetes
+	clients    *InfinispanClientFactory
+	eventRec   record.EventRecorder
+}
+
+type taskRequest struct {
+	*TaskReconciler
+	*clusterResource
+	task       *v2alpha1.Task
+	ispnClient api.Infinispan
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TaskReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	r.Client = mgr.GetClient()
+	r.log = ctrl.Log.WithName("controllers").WithName("Task")
+	r.scheme = mgr.GetScheme()
+	r.kubernetes = kube.NewKubernetesFromController(mgr)
+	r.clients = NewInfinispanClientFactory(r.kubernetes)
+	r.eventRec = mgr.GetEventRecorderFor("task-controller")
+
+	newList := func() client.ObjectList { return &v2alpha1.TaskList{} }
+	builder := ctrl.NewControllerManagedBy(mgr).For(&v2alpha1.Task{})
+	err := watchClusterResources(ctx, mgr, builder, r.kubernetes, r.log, &v2alpha1.Task{}, newList,
+		func(obj client.Object) string { return obj.(*v2alpha1.Task).Spec.ClusterName },
+	)
+	if err != nil {
+		return err
+	}
+	err = watchConfigMapKeyRefs(ctx, mgr, builder, r.kubernetes, r.log, &v2alpha1.Task{}, newList,
+		func(obj client.Object) string { return obj.(*v2alpha1.Task).Spec.ConfigMapKeyRef.Name },
+	)
+	if err != nil {
+		return err
+	}
+	return builder.Complete(r)
+}
+
+// +kubebuilder:rbac:groups=infinispan.org,namespace=infinispan-operator-system,resources=tasks;tasks/status;tasks/finalizers,verbs=get;list;watch;create;update;patch;delete
+
+func (r *TaskReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	reqLogger := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("+++++ Reconciling Task.")
+	defer reqLogger.Info("----- End Reconciling Task.")
+
+	// Fetch the Task instance
+	instance := &v2alpha1.Task{}
+	if err := r.Client.Get(ctx, request.NamespacedName, instance); err != nil {
+		if errors.IsNotFound(err) {
+			reqLogger.Info("Task resource not found. Ignoring it since the object must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// The server REST API does not provide a way to remove tasks, so uploaded scripts are retained on deletion
+	if instance.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	task := &taskRequest{
+		TaskReconciler: r,
+		clusterResource: &clusterResource{
+			client:    r.Client,
+			ctx:       ctx,
+			object:    instance,
+			reqLogger: reqLogger,
+			kind:      "task",
+			setReady: func(status metav1.ConditionStatus, message string) {
+				instance.SetCondition(v2alpha1.TaskConditionReady, status, message)
+			},
+		},
+		task: instance,
+	}
+
+	ispnClient, err := task.infinispanClient(instance.Spec.ClusterName, r.clients)
+	if ispnClient == nil {
+		return ctrl.Result{}, err
+	}
+	task.ispnClient = ispnClient
+
+	if err := task.ispnCreateOrUpdate(); err != nil {
+		reqLogger.Error(err, "Unable to reconcile Task")
+		// The ConfigMap watch ensures that a request is queued once the ConfigMap is created
+		return ctrl.Result{Requeue: !errors.IsNotFound(err)}, task.updateReady(metav1.ConditionFalse, err.Error(), false)
+	}
+	return task.execute()
+}
+
+// ispnCreateOrUpdate uploads the task script to the server if it does not exist on the server or its content has changed.
+// The server is checked even if the digest is unchanged, as the task is lost if the cluster is recreated
+func (r *taskRequest) ispnCreateOrUpdate() error {
+	script, err := r.configMapValue(&r.task.Spec.ConfigMapKeyRef)
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.Sum256([]byte(script))
+	digest := hex.EncodeToString(hash[:])
+	task := r.task
+	upload := task.Status.ScriptDigest != digest
+	if !upload {
+		exists, err := r.ispnExists()
+		if err != nil {
+			return err
+		}
+		upload = !exists
+	}
+	if upload {
+		if err := r.ispnClient.Tasks().Create(task.GetTaskName(), script); err != nil {
+			return fmt.Errorf("unable to upload task script: %w", err)
+		}
+	}
+	return r.update(func() error {
+		task.Status.ScriptDigest = digest
+		r.setReady(metav1.ConditionTrue, "")
+		return nil
+	})
+}
+
+// ispnExists returns true if the task has been uploaded to the server
+func (r *taskRequest) ispnExists() (bool, error) {
+	tasks, err := r.ispnClient.Tasks().List(api.TaskTypeUser)
+	if err != nil {
+		return false, fmt.Errorf("unable to list tasks: %w", err)
+	}
+	name := r.task.GetTaskName()
+	for _, t := range tasks {
+		if t.Name == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// execute runs the task if it has not been executed yet, or if its next scheduled execution is due. The returned
+// Result ensures that the request is requeued in time for the next scheduled execution.
+func (r *taskRequest) execute() (ctrl.Result, error) {
+	task := r.task
+	run := task.Spec.Run
+	if run == nil {
+		return ctrl.Result{}, nil
+	}
+
+	now := time.Now()
+	if run.Schedule == "" {
+		if task.Status.LastExecutionTime != nil {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, r.exec(now, nil)
+	}
+
+	schedule, err := cron.ParseStandard(run.Schedule)
+	if err != nil {
+		return ctrl.Result{}, r.updateReady(metav1.ConditionFalse, fmt.Sprintf("invalid schedule '%s': %v", run.Schedule, err), false)
+	}
+
+	last := task.CreationTimestamp.Time
+	if task.Status.LastExecutionTime != nil {
+		last = task.Status.LastExecutionTime.Time
+	}
+
+	next := schedule.Next(last)
+	if !next.After(now) {
+		next = schedule.Next(now)
+		if err := r.exec(now, &next); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else if task.Status.NextExecutionTime == nil || !task.Status.NextExecutionTime.Time.Equal(next) {
+		if err := r.update(func() error {
+			task.Status.NextExecutionTime = &metav1.Time{Time: next}
+			return nil
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{RequeueAfter: time.Until(next)}, nil
+}
+
+// exec executes the task on the server and records the outcome in the Task status
+func (r *taskRequest) exec(now time.Time, next *time.Time) error {
+	task := r.task
+	name := task.GetTaskName()
+	result, execErr := r.ispnClient.Tasks().Exec(name, task.Spec.Run.Parameters)
+	if execErr != nil {
+		r.reqLogger.Error(execErr, "Task execution failed")
+		r.eventRec.Event(task, corev1.EventTypeWarning, "TaskFailed", fmt.Sprintf("Execution of task '%s' failed: %v", name, execErr))
+	} else {
+		r.eventRec.Event(task, corev1.EventTypeNormal, "TaskExecuted", fmt.Sprintf("Task '%s' executed", name))
+	}
+
+	return r.update(func() error {
+		task.Status.LastExecutionTime = &metav1.Time{Time: now}
+		if next != nil {
+			task.Status.NextExecutionTime = &metav1.Time{Time: *next}
+		} else {
+			task.Status.NextExecutionTime = nil
+		}
+		if execErr != nil {
+			task.Status.Result = ""
+			task.SetCondition(v2alpha1.TaskConditionSucceeded, metav1.ConditionFalse, execErr.Error())
+		} else {
+			task.Status.Result = truncateTaskResult(result)
+			task.SetCondition(v2alpha1.TaskConditionSucceeded, metav1.ConditionTrue, "")
+		}
+		return nil
+	})
+}
+
+// truncateTaskResult limits the result to maxTaskResultLength bytes without splitting a multi-byte UTF-8 character
+func truncateTaskResult(result string) string {
+	if len(result) <= maxTaskResultLength {
+		return result
+	}
+	end := maxTaskResultLength
+	for end > 0 && !utf8.RuneStart(result[end]) {
+		end--
+	}
+	return result[:end]
+}
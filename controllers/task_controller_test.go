@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/fake"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// testTaskScript contains quotes and escape sequences, which must be uploaded verbatim
+const testTaskScript = "// mode=local,language=javascript\nvar message = 'it\\'s \"done\"\\n';\nx + 1"
+
+// testTask returns a Task with the script in the "tasks" ConfigMap, which is also returned
+func testTask(run *v2alpha1.TaskRunSpec) (*v2alpha1.Task, *corev1.ConfigMap) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tasks", Namespace: namespace},
+		Data:       map[string]string{"increment.js": testTaskScript},
+	}
+	task := &v2alpha1.Task{
+		ObjectMeta: testObjectMeta("increment"),
+		Spec: v2alpha1.TaskSpec{
+			ClusterName: "example",
+			ConfigMapKeyRef: corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "tasks"},
+				Key:                  "increment.js",
+			},
+			Run: run,
+		},
+	}
+	return task, configMap
+}
+
+func TestTaskReconcilerUploadsAndExecutesTask(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	server.Update(func(s *fake.State) {
+		// The outdated script is replaced on upload, whilst the configured Result is retained
+		s.Tasks["increment"] = &fake.Task{Script: "outdated", Result: "2"}
+	})
+
+	task, configMap := testTask(&v2alpha1.TaskRunSpec{Parameters: map[string]string{"x": "1"}})
+	k8sClient, clients := testClients(t, server, testInfinispan(), configMap, task)
+	r := TaskReconciler(newTestReconciler(k8sClient, clients))
+
+	request := testRequest(task)
+	_, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+
+	server.Update(func(s *fake.State) {
+		assert.Equal(t, testTaskScript, s.Tasks["increment"].Script)
+		assert.Equal(t, []map[string]string{{"x": "1"}}, s.Tasks["increment"].Executions)
+	})
+
+	updated := &v2alpha1.Task{}
+	assert.NoError(t, k8sClient.Get(context.TODO(), request.NamespacedName, updated))
+	assert.NotEmpty(t, updated.Status.ScriptDigest)
+	assert.NotNil(t, updated.Status.LastExecutionTime)
+	assert.Nil(t, updated.Status.NextExecutionTime)
+	assert.Equal(t, "2", updated.Status.Result)
+	assert.Equal(t, []v2alpha1.TaskCondition{
+		{Type: v2alpha1.TaskConditionReady, Status: metav1.ConditionTrue},
+		{Type: v2alpha1.TaskConditionSucceeded, Status: metav1.ConditionTrue},
+	}, updated.Status.Conditions)
+
+	// Tasks without a schedule are only executed once
+	_, err = r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	server.Update(func(s *fake.State) {
+		assert.Len(t, s.Tasks["increment"].Executions, 1)
+	})
+}
+
+func TestTaskReconcilerReuploadsLostTask(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	task, configMap := testTask(nil)
+	k8sClient, clients := testClients(t, server, testInfinispan(), configMap, task)
+	r := TaskReconciler(newTestReconciler(k8sClient, clients))
+
+	request := testRequest(task)
+	_, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+
+	// The task is uploaded again if the cluster has been recreated, even though the digest is unchanged
+	server.Update(func(s *fake.State) {
+		delete(s.Tasks, "increment")
+	})
+	_, err = r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	server.Update(func(s *fake.State) {
+		assert.Contains(t, s.Tasks, "increment")
+		assert.Empty(t, s.Tasks["increment"].Executions)
+	})
+}
+
+func TestTaskReconcilerScheduledTask(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	task, configMap := testTask(&v2alpha1.TaskRunSpec{Schedule: "0 0 * * *"})
+	k8sClient, clients := testClients(t, server, testInfinispan(), configMap, task)
+	r := TaskReconciler(newTestReconciler(k8sClient, clients))
+
+	request := testRequest(task)
+	result, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.True(t, result.RequeueAfter > 0)
+
+	// The first execution is not due until the next scheduled time after the Task was created
+	server.Update(func(s *fake.State) {
+		assert.Empty(t, s.Tasks["increment"].Executions)
+	})
+	updated := &v2alpha1.Task{}
+	assert.NoError(t, k8sClient.Get(context.TODO(), request.NamespacedName, updated))
+	assert.Nil(t, updated.Status.LastExecutionTime)
+	assert.NotNil(t, updated.Status.NextExecutionTime)
+}
+
+func TestTaskReconcilerConfigMapNotFound(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	task, _ := testTask(nil)
+	k8sClient, clients := testClients(t, server, testInfinispan(), task)
+	r := TaskReconciler(newTestReconciler(k8sClient, clients))
+
+	request := testRequest(task)
+	result, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.False(t, result.Requeue)
+
+	server.Update(func(s *fake.State) {
+		assert.Empty(t, s.Tasks)
+	})
+	updated := &v2alpha1.Task{}
+	assert.NoError(t, k8sClient.Get(context.TODO(), request.NamespacedName, updated))
+	assert.Equal(t, metav1.ConditionFalse, updated.Status.Conditions[0].Status)
+}
+
+func TestTruncateTaskResult(t *testing.T) {
+	assert.Equal(t, "résultat", truncateTaskResult("résultat"))
+
+	ascii := strings.Repeat("a", maxTaskResultLength+1)
+	assert.Equal(t, ascii[:maxTaskResultLength], truncateTaskResult(ascii))
+
+	// "é" is two bytes, so the limit falls within the final character which must be removed entirely
+	result := strings.Repeat("a", maxTaskResultLength-1) + "éé"
+	truncated := truncateTaskResult(result)
+	assert.True(t, utf8.ValidString(truncated))
+	assert.Equal(t, strings.Repeat("a", maxTaskResultLength-1), truncated)
+}
@@ -3,6 +3,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -10,6 +11,7 @@ import (
 	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
 	consts "github.com/infinispan/infinispan-operator/controllers/constants"
 	"github.com/infinispan/infinispan-operator/pkg/http/curl"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -19,20 +21,24 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func (r *infinispanRequest) GetCrossSiteViewCondition(podList *corev1.PodList, siteLocations []string, curl *curl.Client) (*ispnv1.InfinispanCondition, error) {
+// GetCrossSiteViewCondition returns the CrossSiteViewFormed condition and the sites in the cross-site view. The returned
+// view is nil if it could not be retrieved from the coordinator
+func (r *infinispanRequest) GetCrossSiteViewCondition(podList *corev1.PodList, siteLocations []string, curl *curl.Client) (*ispnv1.InfinispanCondition, map[string]bool, error) {
 	for _, item := range podList.Items {
-		cacheManager, err := InfinispanForPod(item.Name, curl).Container().Info()
+		cacheManager, err := InfinispanForPod(item.Name, r.infinispan, curl).Container().Info()
 		if err == nil {
 			if cacheManager.Coordinator {
 				// Perform cross-site view validation
 				crossSiteViewFormed := &ispnv1.InfinispanCondition{Type: ispnv1.ConditionCrossSiteViewFormed, Status: metav1.ConditionTrue}
-				sitesView := make(map[string]bool)
+				var sitesView map[string]bool
 				var err error
 				if cacheManager.SitesView == nil {
 					err = fmt.Errorf("retrieving the cross-site view is not supported with the server image you are using")
-				}
-				for _, site := range *cacheManager.SitesView {
-					sitesView[site.(string)] = true
+				} else {
+					sitesView = make(map[string]bool)
+					for _, site := range *cacheManager.SitesView {
+						sitesView[site.(string)] = true
+					}
 				}
 				if err == nil {
 					for _, location := range siteLocations {
@@ -49,11 +55,161 @@ func (r *infinispanRequest) GetCrossSiteViewCondition(podList *corev1.PodList, s
 					crossSiteViewFormed.Status = metav1.ConditionUnknown
 					crossSiteViewFormed.Message = fmt.Sprintf("Error: %s", err.Error())
 				}
-				return crossSiteViewFormed, nil
+				return crossSiteViewFormed, sitesView, nil
+			}
+		}
+	}
+	return &ispnv1.InfinispanCondition{Type: ispnv1.ConditionCrossSiteViewFormed, Status: metav1.ConditionFalse, Message: "Coordinator not ready"}, nil, nil
+}
+
+// GetCrossSiteStatus returns the state of each remote site, ordered by site name. The state transfer status of the
+// provided existing sites is retained
+func GetCrossSiteStatus(xsite api.Xsite, existing []ispnv1.CrossSiteStatus) ([]ispnv1.CrossSiteStatus, error) {
+	statuses, err := xsite.Status()
+	if err != nil {
+		return nil, err
+	}
+	sites := make([]ispnv1.CrossSiteStatus, 0, len(statuses))
+	for name, status := range statuses {
+		site := ispnv1.CrossSiteStatus{
+			Name:          name,
+			Status:        string(status.Status),
+			OnlineCaches:  status.Online,
+			OfflineCaches: status.Offline,
+			MixedCaches:   status.Mixed,
+		}
+		for _, s := range existing {
+			if s.Name == name {
+				site.StateTransfer = s.StateTransfer
+			}
+		}
+		sites = append(sites, site)
+	}
+	sort.Slice(sites, func(i, j int) bool {
+		return sites[i].Name < sites[j].Name
+	})
+	return sites, nil
+}
+
+// reconcileCrossSiteStateTransfer pushes the state of the local caches to remote sites that have rejoined the cross-site
+// view. Sites that have previously been part of the view, and are therefore present in the provided sites, are marked
+// as Pending when they leave the view. Once a Pending site rejoins, it's brought online and state is pushed to it, with
+// the progress of the transfer recorded until all caches have completed. The updated sites are returned, along with
+// true if a state transfer is still in progress.
+func (r *infinispanRequest) reconcileCrossSiteStateTransfer(sites []ispnv1.CrossSiteStatus, sitesView map[string]bool, ispnClient api.Infinispan) ([]ispnv1.CrossSiteStatus, bool, error) {
+	if sitesView == nil {
+		// The view could not be retrieved, so it's not possible to determine which sites have left or rejoined
+		return sites, false, nil
+	}
+
+	// Operate on a copy as the Infinispan status is overwritten with the latest server state when it's updated
+	sitesCopy := make([]ispnv1.CrossSiteStatus, len(sites))
+	for i := range sites {
+		sites[i].DeepCopyInto(&sitesCopy[i])
+	}
+	sites = sitesCopy
+
+	inProgress := false
+	for i := range sites {
+		site := &sites[i]
+		transfer := site.StateTransfer
+		if !sitesView[site.Name] {
+			if transfer == nil || transfer.Phase != ispnv1.CrossSiteStateTransferPending {
+				r.reqLogger.Info("Remote site has left the cross-site view, state will be transferred once it rejoins", "site", site.Name)
+				site.StateTransfer = &ispnv1.CrossSiteStateTransferStatus{Phase: ispnv1.CrossSiteStateTransferPending}
 			}
+			continue
+		}
+
+		if transfer == nil {
+			continue
+		}
+
+		var err error
+		switch transfer.Phase {
+		case ispnv1.CrossSiteStateTransferPending:
+			err = r.startCrossSiteStateTransfer(site.Name, transfer, ispnClient)
+		case ispnv1.CrossSiteStateTransferRunning:
+			err = r.crossSiteStateTransferProgress(site.Name, transfer, ispnClient)
 		}
+		if err != nil {
+			return sites, true, err
+		}
+		inProgress = inProgress || transfer.Phase == ispnv1.CrossSiteStateTransferRunning
+	}
+	return sites, inProgress, nil
+}
+
+// startCrossSiteStateTransfer brings a site that has rejoined the cross-site view online and pushes the state of all caches to it
+func (r *infinispanRequest) startCrossSiteStateTransfer(site string, transfer *ispnv1.CrossSiteStateTransferStatus, ispnClient api.Infinispan) error {
+	xsite := ispnClient.Container().Xsite()
+	if err := xsite.BringOnline(site); err != nil {
+		return fmt.Errorf("unable to bring site '%s' online: %w", site, err)
+	}
+	if err := xsite.PushState(site); err != nil {
+		return fmt.Errorf("unable to push state to site '%s': %w", site, err)
+	}
+
+	now := metav1.Now()
+	*transfer = ispnv1.CrossSiteStateTransferStatus{
+		Phase:     ispnv1.CrossSiteStateTransferRunning,
+		StartTime: &now,
+	}
+	msg := fmt.Sprintf("Site '%s' rejoined the cross-site view, pushing state", site)
+	r.reqLogger.Info(msg)
+	r.eventRec.Event(r.infinispan, corev1.EventTypeNormal, EventReasonCrossSiteStateTransferStarted, msg)
+	return nil
+}
+
+// crossSiteStateTransferProgress updates the progress of a running state transfer using the push state status of each cache
+func (r *infinispanRequest) crossSiteStateTransferProgress(site string, transfer *ispnv1.CrossSiteStateTransferStatus, ispnClient api.Infinispan) error {
+	cacheNames, err := ispnClient.Caches().Names()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve cache names: %w", err)
 	}
-	return &ispnv1.InfinispanCondition{Type: ispnv1.ConditionCrossSiteViewFormed, Status: metav1.ConditionFalse, Message: "Coordinator not ready"}, nil
+	sort.Strings(cacheNames)
+
+	var total, completed int32
+	var failed []string
+	for _, cacheName := range cacheNames {
+		statuses, err := ispnClient.Cache(cacheName).Xsite().PushStateStatus()
+		if err != nil {
+			return fmt.Errorf("unable to retrieve push state status of cache '%s': %w", cacheName, err)
+		}
+		// Caches that don't back up to the site have no status
+		status, ok := statuses[site]
+		if !ok {
+			continue
+		}
+		total++
+		switch status {
+		case api.PushStateStatusOK:
+			completed++
+		case api.PushStateStatusError, api.PushStateStatusCancelling:
+			failed = append(failed, cacheName)
+		}
+	}
+
+	transfer.TotalCaches = total
+	transfer.CompletedCaches = completed
+	transfer.FailedCaches = failed
+	if completed+int32(len(failed)) < total {
+		transfer.Message = fmt.Sprintf("Pushed state of %d/%d caches", completed, total)
+		return nil
+	}
+
+	now := metav1.Now()
+	transfer.CompletionTime = &now
+	if len(failed) > 0 {
+		transfer.Phase = ispnv1.CrossSiteStateTransferFailed
+		transfer.Message = fmt.Sprintf("Unable to push state of caches: %s", strings.Join(failed, ","))
+		r.eventRec.Event(r.infinispan, corev1.EventTypeWarning, EventReasonCrossSiteStateTransferFailed, fmt.Sprintf("State transfer to site '%s' failed. %s", site, transfer.Message))
+	} else {
+		transfer.Phase = ispnv1.CrossSiteStateTransferSucceeded
+		transfer.Message = fmt.Sprintf("Pushed state of %d caches", total)
+		r.eventRec.Event(r.infinispan, corev1.EventTypeNormal, EventReasonCrossSiteStateTransferSucceeded, fmt.Sprintf("State transfer to site '%s' completed. %s", site, transfer.Message))
+	}
+	return nil
 }
 
 // GetGossipRouterDeployment returns the deployment for the Gossip Router pod
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -16,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -35,14 +37,28 @@ type zeroCapacityResource interface {
 	UpdatePhase(phase zeroCapacityPhase, phaseErr error) error
 	// Transform any CR resources, adding defaults or updating fields for backwards-compatibility if required
 	Transform() (bool, error)
+	// Create any resources that must exist before the Infinispan cluster is loaded, e.g. recreating the cluster itself.
+	// Returns true once the resources are available, otherwise false
+	Bootstrap() (bool, error)
 	// Ensure that all prerequisite resources are av¬ailable and create any required resources before returning the zero spec
 	Init() (*zeroCapacitySpec, error)
+	// Perform any operation(s) that must complete on the zero-capacity pod before Exec is called, e.g. retrieving content
+	PreExec(client api.Infinispan) error
 	// Perform the operation(s) that are required on the zero-capacity pod
 	Exec(client api.Infinispan) error
+	// Perform any operation(s) that must complete on the zero-capacity pod once Exec has succeeded, e.g. exporting content.
+	// Errors marked as transient cause PostExec to be retried, otherwise the CR is marked as Failed
+	PostExec(client api.Infinispan) error
 	// Return true when the operation(s) have completed, otherwise false
 	ExecStatus(api api.Infinispan) (zeroCapacityPhase, error)
-	// Utility method to return a metav1.Object in order to set the controller reference
-	AsMeta() metav1.Object
+	// Cancel any operation(s) in progress on the zero-capacity pod and remove partial content. Returns true once the
+	// server has acknowledged the cancellation, otherwise false
+	Cancel(client api.Infinispan) (bool, error)
+	// Remove any content created by the operation outside of the cluster's volumes when the CR is deleted, e.g. objects
+	// uploaded to external storage
+	Finalize() error
+	// Utility method to return a client.Object in order to set the controller reference and manage finalizers
+	AsMeta() client.Object
 }
 
 type zeroCapacityReconciler interface {
@@ -66,6 +82,8 @@ type zeroCapacityVolumeSpec struct {
 	UpdatePermissions bool
 	// Path within the container at which the volume should be mounted.
 	MountPath string
+	// If true the volume is mounted read-only
+	ReadOnly bool
 	// The VolumeSource to utilise on the zero-capacity pod
 	VolumeSource corev1.VolumeSource
 }
@@ -75,6 +93,7 @@ type zeroCapacityController struct {
 	Name       string
 	Reconciler zeroCapacityReconciler
 	Kube       *kube.Kubernetes
+	Clients    *InfinispanClientFactory
 	Log        logr.Logger
 	Scheme     *runtime.Scheme
 	EventRec   record.EventRecorder
@@ -99,12 +118,41 @@ const (
 	ZeroUnknown zeroCapacityPhase = "Unknown"
 )
 
+// transientError wraps an error caused by a condition that is expected to resolve itself, e.g. a network failure, so
+// that the operation is retried instead of the CR being marked as Failed
+type transientError struct {
+	err error
+}
+
+func (e *transientError) Error() string {
+	return e.err.Error()
+}
+
+func (e *transientError) Unwrap() error {
+	return e.err
+}
+
+// transient marks err as a transientError, nil is returned if err is nil
+func transient(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &transientError{err}
+}
+
+func isTransient(err error) bool {
+	var e *transientError
+	return stderrors.As(err, &e)
+}
+
 func newZeroCapacityController(name string, reconciler zeroCapacityReconciler, mgr ctrl.Manager) error {
+	k8s := kube.NewKubernetesFromController(mgr)
 	r := &zeroCapacityController{
 		Name:       name,
 		Client:     mgr.GetClient(),
 		Reconciler: reconciler,
-		Kube:       kube.NewKubernetesFromController(mgr),
+		Kube:       k8s,
+		Clients:    NewInfinispanClientFactory(k8s),
 		Log:        ctrl.Log.WithName("controllers").WithName(name),
 		Scheme:     mgr.GetScheme(),
 		EventRec:   mgr.GetEventRecorderFor(strings.ToLower(name) + "-controller"),
@@ -139,6 +187,19 @@ func (z *zeroCapacityController) Reconcile(ctx context.Context, request reconcil
 	}
 
 	phase := instance.Phase()
+	meta := instance.AsMeta()
+	if !meta.GetDeletionTimestamp().IsZero() {
+		if controllerutil.ContainsFinalizer(meta, consts.InfinispanFinalizer) {
+			return z.cancel(request, instance, ctx)
+		}
+		return reconcile.Result{}, nil
+	}
+
+	// Add the finalizer to operations that have not completed, so that they're cancelled if the CR is deleted
+	if phase != ZeroSucceeded && phase != ZeroFailed && !controllerutil.ContainsFinalizer(meta, consts.InfinispanFinalizer) {
+		return reconcile.Result{}, z.updateFinalizer(meta, resource, ctx, controllerutil.AddFinalizer)
+	}
+
 	switch phase {
 	case "":
 		// Perform any transformations required on the CR for backwards-compatibility. Returning if a tranformation or error occurs
@@ -169,7 +230,7 @@ func (z *zeroCapacityController) Reconcile(ctx context.Context, request reconcil
 	}
 
 	podName := instance.AsMeta().GetName()
-	ispnClient, err := NewInfinispanForPod(ctx, podName, infinispan, z.Kube)
+	ispnClient, err := z.Clients.NewInfinispanForPod(ctx, podName, infinispan)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -194,6 +255,13 @@ func (z *zeroCapacityController) initializeResources(request reconcile.Request,
 		Name:      clusterName,
 	}
 
+	if ready, err := instance.Bootstrap(); err != nil {
+		z.Log.Error(err, "unable to bootstrap zero-capacity resources", "request.Name", name)
+		return reconcile.Result{}, instance.UpdatePhase(ZeroFailed, err)
+	} else if !ready {
+		return reconcile.Result{RequeueAfter: consts.DefaultWaitOnCluster}, nil
+	}
+
 	infinispan := &v1.Infinispan{}
 	if err := z.Client.Get(ctx, clusterKey, infinispan); err != nil {
 		z.Log.Info(fmt.Sprintf("Unable to load Infinispan Cluster '%s': %s", clusterName, err))
@@ -246,6 +314,11 @@ func (z *zeroCapacityController) execute(ispnClient api.Infinispan, request reco
 		return reconcile.Result{}, nil
 	}
 
+	if err := instance.PreExec(ispnClient); err != nil {
+		z.Log.Error(err, "unable to prepare zero-capacity pod", "request.Name", request.Name)
+		return reconcile.Result{}, instance.UpdatePhase(ZeroFailed, err)
+	}
+
 	if err := instance.Exec(ispnClient); err != nil {
 		z.Log.Error(err, "unable to execute action on zero-capacity pod", "request.Name", request.Name)
 		return reconcile.Result{}, instance.UpdatePhase(ZeroFailed, err)
@@ -263,6 +336,14 @@ func (z *zeroCapacityController) waitForExecutionToComplete(ispnClient api.Infin
 	}
 
 	if phase == ZeroSucceeded {
+		if err := instance.PostExec(ispnClient); err != nil {
+			if isTransient(err) {
+				// PostExec is executed again once the request is requeued, so it must be idempotent
+				return reconcile.Result{}, err
+			}
+			z.Log.Error(err, "post execution failed", "request.Name", request.Name)
+			return reconcile.Result{}, instance.UpdatePhase(ZeroFailed, err)
+		}
 		return reconcile.Result{}, instance.UpdatePhase(ZeroSucceeded, nil)
 	}
 
@@ -282,6 +363,74 @@ func (z *zeroCapacityController) cleanupResources(ispnClient api.Infinispan, req
 	return reconcile.Result{}, nil
 }
 
+func (z *zeroCapacityController) cancel(request reconcile.Request, instance zeroCapacityResource, ctx context.Context) (reconcile.Result, error) {
+	meta := instance.AsMeta()
+	resource := reflect.TypeOf(z.Reconciler.Type()).Elem().Name()
+
+	switch instance.Phase() {
+	case ZeroInitialized, ZeroRunning, ZeroUnknown:
+		// The operation can only be cancelled via the zero-capacity server, if the pod is not ready then the operation
+		// cannot be in progress
+		if !z.isZeroPodReady(request, ctx) {
+			break
+		}
+
+		infinispan := &v1.Infinispan{}
+		if err := z.Get(ctx, types.NamespacedName{Namespace: request.Namespace, Name: instance.Cluster()}, infinispan); err != nil {
+			if errors.IsNotFound(err) {
+				break
+			}
+			return reconcile.Result{}, fmt.Errorf("unable to fetch CR '%s': %w", instance.Cluster(), err)
+		}
+
+		ispnClient, err := z.Clients.NewInfinispanForPod(ctx, request.Name, infinispan)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+
+		cancelled, err := instance.Cancel(ispnClient)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("unable to cancel %s '%s': %w", resource, request.Name, err)
+		}
+
+		if !cancelled {
+			// Wait 1 second for the server to stop the operation before retrying
+			return reconcile.Result{RequeueAfter: 1 * time.Second}, nil
+		}
+		z.EventRec.Event(meta, corev1.EventTypeNormal, "Cancelled", fmt.Sprintf("%s '%s' cancelled as the CR was deleted", resource, request.Name))
+	}
+
+	// Only remove the zero-capacity pod once the operation has stopped, so that partial content is not left behind
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      request.Name,
+			Namespace: request.Namespace,
+		},
+	}
+	if err := z.Delete(ctx, pod); err != nil && !errors.IsNotFound(err) {
+		return reconcile.Result{}, fmt.Errorf("unable to delete zero-capacity pod: %w", err)
+	}
+
+	if err := instance.Finalize(); err != nil {
+		return reconcile.Result{}, fmt.Errorf("unable to finalize %s '%s': %w", resource, request.Name, err)
+	}
+	return reconcile.Result{}, z.updateFinalizer(meta, resource, ctx, controllerutil.RemoveFinalizer)
+}
+
+func (z *zeroCapacityController) updateFinalizer(obj client.Object, resource string, ctx context.Context, mutate func(client.Object, string)) error {
+	_, err := kube.CreateOrPatch(ctx, z.Client, obj, func() error {
+		if creationTimestamp := obj.GetCreationTimestamp(); creationTimestamp.IsZero() {
+			return errors.NewNotFound(schema.ParseGroupResource(strings.ToLower(resource)+".infinispan.org"), obj.GetName())
+		}
+		mutate(obj, consts.InfinispanFinalizer)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to update finalizer of %s '%s': %w", resource, obj.GetName(), err)
+	}
+	return nil
+}
+
 func (z *zeroCapacityController) isZeroPodReady(request reconcile.Request, ctx context.Context) bool {
 	pod := &corev1.Pod{}
 	if err := z.Get(ctx, request.NamespacedName, pod); err != nil {
@@ -335,6 +484,7 @@ func (z *zeroCapacityController) zeroPodSpec(name, namespace string, podSecurity
 					{
 						Name:      name,
 						MountPath: zeroSpec.Volume.MountPath,
+						ReadOnly:  zeroSpec.Volume.ReadOnly,
 					}, {
 						Name:      InfinispanSecurityVolumeName,
 						MountPath: consts.ServerOperatorSecurity,
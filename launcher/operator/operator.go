@@ -112,6 +112,29 @@ func NewWithContext(ctx context.Context, p Parameters) {
 		setupLog.Error(err, "unable to create controller", "controller", "Cache")
 		os.Exit(1)
 	}
+	if err = (&controllers.CounterReconciler{}).SetupWithManager(ctx, mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Counter")
+		os.Exit(1)
+	}
+	if err = (&controllers.SchemaReconciler{}).SetupWithManager(ctx, mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Schema")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.TaskReconciler{}).SetupWithManager(ctx, mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Task")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.DiagnosticsReconciler{}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Diagnostics")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.BackupScheduleReconciler{}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BackupSchedule")
+		os.Exit(1)
+	}
 
 	if err = (&controllers.SecretReconciler{}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Secret")
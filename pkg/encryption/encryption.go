@@ -0,0 +1,180 @@
+// Package encryption provides streaming authenticated encryption of backup archives. Archives are encrypted with
+// AES-256-GCM in fixed size segments so that archives of arbitrary size can be processed without being buffered.
+//
+// An encrypted archive consists of a header, containing a magic value, the id of the key and a random nonce prefix,
+// followed by the encrypted segments. The nonce of each segment is derived from the nonce prefix, the index of the
+// segment and a flag indicating the final segment, which prevents segments from being reordered or truncated.
+package encryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	Algorithm = "AES-256-GCM"
+	// KeySize is the required size of an encryption key in bytes
+	KeySize = 32
+
+	segmentSize     = 64 * 1024
+	keyIDSize       = 8
+	noncePrefixSize = 7
+)
+
+var magic = []byte("ISPNENC1")
+
+// HeaderSize is the number of bytes preceding the first encrypted segment
+var HeaderSize = len(magic) + keyIDSize + noncePrefixSize
+
+// Header contains the metadata stored at the start of an encrypted archive
+type Header struct {
+	Algorithm   string
+	KeyID       string
+	noncePrefix []byte
+	raw         []byte
+}
+
+// KeyMismatchError is returned when an archive is decrypted with a different key to the one it was encrypted with
+type KeyMismatchError struct {
+	Expected string
+	Actual   string
+}
+
+func (e *KeyMismatchError) Error() string {
+	return fmt.Sprintf("archive was encrypted with key '%s' but key '%s' was provided", e.Expected, e.Actual)
+}
+
+// KeyID returns a fingerprint of the key that can be stored alongside the encrypted content without revealing the key
+func KeyID(key []byte) string {
+	hash := sha256.Sum256(key)
+	return hex.EncodeToString(hash[:keyIDSize])
+}
+
+// IsEncrypted returns true if the content starts with the header of an encrypted archive
+func IsEncrypted(prefix []byte) bool {
+	return bytes.HasPrefix(prefix, magic)
+}
+
+// ReadHeader reads and parses the header of an encrypted archive
+func ReadHeader(src io.Reader) (*Header, error) {
+	raw := make([]byte, HeaderSize)
+	if _, err := io.ReadFull(src, raw); err != nil {
+		return nil, fmt.Errorf("unable to read header: %w", err)
+	}
+	if !IsEncrypted(raw) {
+		return nil, errors.New("content is not an encrypted archive")
+	}
+	keyID := raw[len(magic) : len(magic)+keyIDSize]
+	return &Header{
+		Algorithm:   Algorithm,
+		KeyID:       hex.EncodeToString(keyID),
+		noncePrefix: raw[len(magic)+keyIDSize:],
+		raw:         raw,
+	}, nil
+}
+
+// Encrypt writes the encrypted content of src to dst
+func Encrypt(dst io.Writer, src io.Reader, key []byte) error {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+
+	noncePrefix := make([]byte, noncePrefixSize)
+	if _, err := rand.Read(noncePrefix); err != nil {
+		return fmt.Errorf("unable to generate nonce: %w", err)
+	}
+	keyID, _ := hex.DecodeString(KeyID(key))
+	header := append(append(append([]byte{}, magic...), keyID...), noncePrefix...)
+	if _, err := dst.Write(header); err != nil {
+		return err
+	}
+
+	// The final segment is always shorter than segmentSize, so an empty segment is written if the content is a
+	// multiple of the segment size
+	plaintext := make([]byte, segmentSize)
+	var ciphertext []byte
+	for index := uint32(0); ; index++ {
+		n, err := io.ReadFull(src, plaintext)
+		last := err == io.EOF || err == io.ErrUnexpectedEOF
+		if err != nil && !last {
+			return err
+		}
+		ciphertext = aead.Seal(ciphertext[:0], nonce(noncePrefix, index, last), plaintext[:n], header)
+		if _, err := dst.Write(ciphertext); err != nil {
+			return err
+		}
+		if last {
+			return nil
+		}
+	}
+}
+
+// Decrypt writes the decrypted content of src to dst. A KeyMismatchError is returned if the archive was encrypted
+// with a different key.
+func Decrypt(dst io.Writer, src io.Reader, key []byte) error {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+
+	header, err := ReadHeader(src)
+	if err != nil {
+		return err
+	}
+	if keyID := KeyID(key); keyID != header.KeyID {
+		return &KeyMismatchError{Expected: header.KeyID, Actual: keyID}
+	}
+
+	ciphertext := make([]byte, segmentSize+aead.Overhead())
+	var plaintext []byte
+	for index := uint32(0); ; index++ {
+		n, err := io.ReadFull(src, ciphertext)
+		if err == io.EOF {
+			return errors.New("archive is truncated")
+		}
+		last := err == io.ErrUnexpectedEOF
+		if err != nil && !last {
+			return err
+		}
+		plaintext, err = aead.Open(plaintext[:0], nonce(header.noncePrefix, index, last), ciphertext[:n], header.raw)
+		if err != nil {
+			return fmt.Errorf("unable to decrypt segment %d: %w", index, err)
+		}
+		if _, err := dst.Write(plaintext); err != nil {
+			return err
+		}
+		if last {
+			return nil
+		}
+	}
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("encryption key must be %d bytes, got %d", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func nonce(prefix []byte, index uint32, last bool) []byte {
+	n := make([]byte, noncePrefixSize+5)
+	copy(n, prefix)
+	binary.BigEndian.PutUint32(n[noncePrefixSize:], index)
+	if last {
+		n[len(n)-1] = 1
+	}
+	return n
+}
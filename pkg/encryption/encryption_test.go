@@ -0,0 +1,67 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newKey(t *testing.T) []byte {
+	key := make([]byte, KeySize)
+	_, err := rand.Read(key)
+	assert.NoError(t, err)
+	return key
+}
+
+func TestRoundTrip(t *testing.T) {
+	key := newKey(t)
+	for _, size := range []int{0, 1, segmentSize - 1, segmentSize, segmentSize + 1, 3 * segmentSize} {
+		content := make([]byte, size)
+		_, err := rand.Read(content)
+		assert.NoError(t, err)
+
+		encrypted := &bytes.Buffer{}
+		assert.NoError(t, Encrypt(encrypted, bytes.NewReader(content), key))
+		assert.True(t, IsEncrypted(encrypted.Bytes()))
+
+		header, err := ReadHeader(bytes.NewReader(encrypted.Bytes()))
+		assert.NoError(t, err)
+		assert.Equal(t, KeyID(key), header.KeyID)
+		assert.Equal(t, Algorithm, header.Algorithm)
+
+		decrypted := &bytes.Buffer{}
+		assert.NoError(t, Decrypt(decrypted, bytes.NewReader(encrypted.Bytes()), key), "size %d", size)
+		assert.True(t, bytes.Equal(content, decrypted.Bytes()), "size %d", size)
+	}
+}
+
+func TestWrongKey(t *testing.T) {
+	encrypted := &bytes.Buffer{}
+	assert.NoError(t, Encrypt(encrypted, bytes.NewReader([]byte("content")), newKey(t)))
+
+	err := Decrypt(&bytes.Buffer{}, bytes.NewReader(encrypted.Bytes()), newKey(t))
+	assert.IsType(t, &KeyMismatchError{}, err)
+
+	assert.Error(t, Encrypt(&bytes.Buffer{}, bytes.NewReader(nil), []byte("short")))
+}
+
+func TestTampering(t *testing.T) {
+	key := newKey(t)
+	content := make([]byte, 2*segmentSize+10)
+	encrypted := &bytes.Buffer{}
+	assert.NoError(t, Encrypt(encrypted, bytes.NewReader(content), key))
+
+	// Modified content
+	modified := append([]byte{}, encrypted.Bytes()...)
+	modified[HeaderSize+10] ^= 1
+	assert.Error(t, Decrypt(&bytes.Buffer{}, bytes.NewReader(modified), key))
+
+	// Truncated at a segment boundary
+	truncated := encrypted.Bytes()[:HeaderSize+segmentSize+16]
+	assert.Error(t, Decrypt(&bytes.Buffer{}, bytes.NewReader(truncated), key))
+
+	// Not encrypted
+	assert.Error(t, Decrypt(&bytes.Buffer{}, bytes.NewReader(content), key))
+}
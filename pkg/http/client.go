@@ -10,7 +10,8 @@ import (
 	"github.com/infinispan/infinispan-operator/controllers/constants"
 )
 
-// HttpClient interface containing methods for the most common HTTP methods
+// HttpClient interface containing methods for the most common HTTP methods. Implementations may buffer the entire
+// response body in memory, unless they implement StreamingHttpClient
 type HttpClient interface {
 	Head(path string, headers map[string]string) (*http.Response, error)
 	Get(path string, headers map[string]string) (*http.Response, error)
@@ -19,6 +20,20 @@ type HttpClient interface {
 	Delete(path string, headers map[string]string) (*http.Response, error)
 }
 
+// StreamingHttpClient is implemented by HttpClient implementations that stream the response body from the underlying
+// connection instead of buffering it in memory
+type StreamingHttpClient interface {
+	HttpClient
+	// Streaming returns true if response bodies are streamed
+	Streaming() bool
+}
+
+// IsStreaming returns true if the HttpClient streams response bodies
+func IsStreaming(client HttpClient) bool {
+	s, ok := client.(StreamingHttpClient)
+	return ok && s.Streaming()
+}
+
 // HttpError Error() implementation
 type HttpError struct {
 	Status  int
@@ -30,6 +30,8 @@ type Config struct {
 type Client struct {
 	Config Config
 	*kube.Kubernetes
+	// executor executes commands on the pod, defaults to Kubernetes.ExecWithOptions when nil
+	executor func(options kube.ExecOptions) (bytes.Buffer, error)
 }
 
 func New(c Config, kubernetes *kube.Kubernetes) *Client {
@@ -42,54 +44,57 @@ func New(c Config, kubernetes *kube.Kubernetes) *Client {
 func (c *Client) CloneForPod(podName string) *Client {
 	client := New(c.Config, c.Kubernetes)
 	client.Config.Podname = podName
+	client.executor = c.executor
 	return client
 }
 
 func (c *Client) Get(path string, headers map[string]string) (*http.Response, error) {
-	return c.executeCurlCommand(path, headers)
+	return c.executeCurlCommand(path, headers, nil)
 }
 
 func (c *Client) Head(path string, headers map[string]string) (*http.Response, error) {
-	return c.executeCurlCommand(path, headers, "--head")
+	return c.executeCurlCommand(path, headers, nil, "--head")
 }
 
 func (c *Client) Post(path, payload string, headers map[string]string) (*http.Response, error) {
-	data := ""
-	if payload != "" {
-		data = fmt.Sprintf("-d $'%s'", payload)
-	}
-	return c.executeCurlCommand(path, headers, data, "-X POST")
+	return c.executeCurlWithPayload(path, payload, headers, "-X POST")
 }
 
 func (c *Client) Put(path, payload string, headers map[string]string) (*http.Response, error) {
-	data := ""
-	if payload != "" {
-		data = fmt.Sprintf("-d $'%s'", payload)
-	}
-	return c.executeCurlCommand(path, headers, data, "-X PUT")
+	return c.executeCurlWithPayload(path, payload, headers, "-X PUT")
 }
 
 func (c *Client) Delete(path string, headers map[string]string) (*http.Response, error) {
-	return c.executeCurlCommand(path, headers, "-X DELETE")
+	return c.executeCurlCommand(path, headers, nil, "-X DELETE")
+}
+
+// executeCurlWithPayload streams the payload to curl via stdin, so that it's sent verbatim without being interpreted by
+// bash. The payload is read in its entirety by curl, so it can be resent once the digest challenge has been received
+func (c *Client) executeCurlWithPayload(path, payload string, headers map[string]string, args ...string) (*http.Response, error) {
+	if payload == "" {
+		return c.executeCurlCommand(path, headers, nil, args...)
+	}
+	return c.executeCurlCommand(path, headers, strings.NewReader(payload), append(args, "--data-binary @-")...)
 }
 
-func (c *Client) executeCurlCommand(path string, headers map[string]string, args ...string) (*http.Response, error) {
-	httpURL := fmt.Sprintf("%s://%s:%d/%s", c.Config.Protocol, c.Config.Podname, c.Config.Port, path)
+func (c *Client) executeCurlCommand(path string, headers map[string]string, stdin io.Reader, args ...string) (*http.Response, error) {
+	// The URL is quoted as the command is executed by bash, where '&' in the query would otherwise be interpreted
+	httpURL := shellQuote(fmt.Sprintf("%s://%s:%d/%s", c.Config.Protocol, c.Config.Podname, c.Config.Port, path))
 
 	headerStr := headerString(headers)
 	argStr := strings.Join(args, " ")
 
 	if c.Config.Credentials != nil {
-		return c.executeCurlWithAuth(httpURL, headerStr, argStr)
+		return c.executeCurlWithAuth(httpURL, headerStr, argStr, stdin)
 	}
-	return c.executeCurlNoAuth(httpURL, headerStr, argStr)
+	return c.executeCurlNoAuth(httpURL, headerStr, argStr, stdin)
 }
 
-func (c *Client) executeCurlWithAuth(httpURL, headers, args string) (*http.Response, error) {
+func (c *Client) executeCurlWithAuth(httpURL, headers, args string, stdin io.Reader) (*http.Response, error) {
 	user := fmt.Sprintf("-u %v:%v", c.Config.Credentials.Username, c.Config.Credentials.Password)
 	curl := fmt.Sprintf("curl -i --insecure --digest --http1.1 %s %s %s %s", user, headers, args, httpURL)
 
-	execOut, err := c.exec(curl)
+	execOut, err := c.exec(curl, stdin)
 	if err != nil {
 		return nil, err
 	}
@@ -107,9 +112,9 @@ func (c *Client) executeCurlWithAuth(httpURL, headers, args string) (*http.Respo
 	return handleContent(reader)
 }
 
-func (c *Client) executeCurlNoAuth(httpURL, headers, args string) (*http.Response, error) {
+func (c *Client) executeCurlNoAuth(httpURL, headers, args string, stdin io.Reader) (*http.Response, error) {
 	curl := fmt.Sprintf("curl -i --insecure --http1.1 %s %s %s", headers, args, httpURL)
-	execOut, err := c.exec(curl)
+	execOut, err := c.exec(curl, stdin)
 	if err != nil {
 		return nil, err
 	}
@@ -118,14 +123,23 @@ func (c *Client) executeCurlNoAuth(httpURL, headers, args string) (*http.Respons
 	return handleContent(reader)
 }
 
-func (c *Client) exec(cmd string) (bytes.Buffer, error) {
-	return c.Kubernetes.ExecWithOptions(
-		kube.ExecOptions{
-			Container: c.Config.Container,
-			Command:   []string{"bash", "-c", cmd},
-			PodName:   c.Config.Podname,
-			Namespace: c.Config.Namespace,
-		})
+func (c *Client) exec(cmd string, stdin io.Reader) (bytes.Buffer, error) {
+	options := kube.ExecOptions{
+		Container: c.Config.Container,
+		Command:   []string{"bash", "-c", cmd},
+		PodName:   c.Config.Podname,
+		Namespace: c.Config.Namespace,
+		Stdin:     stdin,
+	}
+	if c.executor != nil {
+		return c.executor(options)
+	}
+	return c.Kubernetes.ExecWithOptions(options)
+}
+
+// shellQuote returns s as a single-quoted bash word, so that it's passed to the command verbatim
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
 }
 
 func handleContent(reader *bufio.Reader) (*http.Response, error) {
@@ -152,7 +166,8 @@ func headerString(headers map[string]string) string {
 	}
 	b := new(bytes.Buffer)
 	for key, value := range headers {
-		fmt.Fprintf(b, "-H \"%s: %s\" ", key, value)
+		// Escape quotes so that quoted values, such as ETags, are passed to curl verbatim
+		fmt.Fprintf(b, "-H \"%s: %s\" ", key, strings.ReplaceAll(value, `"`, `\"`))
 	}
 	return b.String()
 }
@@ -0,0 +1,214 @@
+package curl
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	v13 "github.com/infinispan/infinispan-operator/pkg/infinispan/client/v13"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingServer responds to all requests with the configured body and records the URL and payload of the last
+// request
+type recordingServer struct {
+	*httptest.Server
+	mutex   sync.Mutex
+	url     *url.URL
+	payload string
+	body    string
+}
+
+func newRecordingServer(body string) *recordingServer {
+	s := &recordingServer{body: body}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := ioutil.ReadAll(r.Body)
+		s.mutex.Lock()
+		s.url = r.URL
+		s.payload = string(payload)
+		body := s.body
+		s.mutex.Unlock()
+		_, _ = w.Write([]byte(body))
+	}))
+	return s
+}
+
+func (s *recordingServer) setBody(body string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.body = body
+}
+
+func (s *recordingServer) lastPayload() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.payload
+}
+
+func (s *recordingServer) query() url.Values {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.url.Query()
+}
+
+// localClient returns a Client that executes the curl commands with the local bash, in the same way as they are
+// executed on a server pod
+func localClient(t *testing.T, server *httptest.Server) *Client {
+	if _, err := exec.LookPath("curl"); err != nil {
+		t.Skip("curl is not installed")
+	}
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	assert.NoError(t, err)
+	portNum, err := strconv.Atoi(port)
+	assert.NoError(t, err)
+
+	client := New(Config{
+		Podname:  host,
+		Port:     portNum,
+		Protocol: "http",
+	}, nil)
+	client.executor = func(options kube.ExecOptions) (bytes.Buffer, error) {
+		var stdout bytes.Buffer
+		cmd := exec.Command(options.Command[0], options.Command[1:]...)
+		cmd.Stdin = options.Stdin
+		cmd.Stdout = &stdout
+		err := cmd.Run()
+		return stdout, err
+	}
+	return client
+}
+
+func TestQueryWithMultipleParameters(t *testing.T) {
+	server := newRecordingServer("body")
+	defer server.Close()
+
+	client := localClient(t, server.Server)
+	rsp, err := client.Get("rest/v2/caches/test?action=search&query=from%20Person%20where%20name%20%3D%20%27O%27%27Brien%27&max_results=10", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rsp.StatusCode)
+	body, err := ioutil.ReadAll(rsp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "body", string(body))
+
+	query := server.query()
+	assert.Equal(t, "search", query.Get("action"))
+	assert.Equal(t, "from Person where name = 'O''Brien'", query.Get("query"))
+	assert.Equal(t, "10", query.Get("max_results"))
+}
+
+func TestPayloadIsSentVerbatim(t *testing.T) {
+	server := newRecordingServer("")
+	defer server.Close()
+
+	marker := filepath.Join(t.TempDir(), "injected")
+	payload := "var s = 'it\\'s';\nprint(\"a\\nb\");\n$(touch " + marker + ")`touch " + marker + "`"
+	client := localClient(t, server.Server)
+	for _, send := range []func(string, string, map[string]string) (*http.Response, error){client.Post, client.Put} {
+		rsp, err := send("rest/v2/tasks/test", payload, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rsp.StatusCode)
+		assert.Equal(t, payload, server.lastPayload())
+	}
+	_, err := os.Stat(marker)
+	assert.True(t, os.IsNotExist(err), "the payload must not be executed by the shell")
+}
+
+func TestSchemaIsSentVerbatim(t *testing.T) {
+	server := newRecordingServer("")
+	defer server.Close()
+
+	schema := "// don't change the \"Person\" message\nmessage Person {\n  optional string name = 1;\n}\n"
+	schemas := v13.New(localClient(t, server.Server)).Schemas()
+	assert.NoError(t, schemas.Register("person.proto", schema))
+	assert.Equal(t, schema, server.lastPayload())
+	assert.NoError(t, schemas.Update("person.proto", schema))
+	assert.Equal(t, schema, server.lastPayload())
+}
+
+func TestShellQuote(t *testing.T) {
+	assert.Equal(t, `'a&b'`, shellQuote("a&b"))
+	assert.Equal(t, `'it'\''s'`, shellQuote("it's"))
+}
+
+func TestSetAvailability(t *testing.T) {
+	server := newRecordingServer("")
+	defer server.Close()
+
+	ispn := v13.New(localClient(t, server.Server))
+	assert.NoError(t, ispn.Cache("test").SetAvailability(api.CacheAvailabilityAvailable))
+	query := server.query()
+	assert.Equal(t, "set-availability", query.Get("action"))
+	assert.Equal(t, string(api.CacheAvailabilityAvailable), query.Get("availability"))
+}
+
+func TestCounterUpdates(t *testing.T) {
+	server := newRecordingServer("5")
+	defer server.Close()
+
+	counters := v13.New(localClient(t, server.Server)).Counters()
+	value, err := counters.AddAndGet("counter", -2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), value)
+	query := server.query()
+	assert.Equal(t, "add", query.Get("action"))
+	assert.Equal(t, "-2", query.Get("delta"))
+
+	server.setBody("true")
+	set, err := counters.CompareAndSet("counter", 5, 7)
+	assert.NoError(t, err)
+	assert.True(t, set)
+	query = server.query()
+	assert.Equal(t, "compareAndSet", query.Get("action"))
+	assert.Equal(t, "5", query.Get("expect"))
+	assert.Equal(t, "7", query.Get("update"))
+}
+
+func TestTaskExecParameters(t *testing.T) {
+	server := newRecordingServer("result")
+	defer server.Close()
+
+	tasks := v13.New(localClient(t, server.Server)).Tasks()
+	result, err := tasks.Exec("task", map[string]string{"a": "1", "b": "x&y"})
+	assert.NoError(t, err)
+	assert.Equal(t, "result", result)
+	query := server.query()
+	assert.Equal(t, "exec", query.Get("action"))
+	assert.Equal(t, "1", query.Get("param.a"))
+	assert.Equal(t, "x&y", query.Get("param.b"))
+}
+
+func TestCacheQuery(t *testing.T) {
+	server := newRecordingServer(`{"total_results":1,"hits":[{"hit":{"name":"O'Brien"}}]}`)
+	defer server.Close()
+
+	cache := v13.New(localClient(t, server.Server)).Cache("test")
+	result, err := cache.Query("from Person where name = 'O''Brien' and age > 18", 5, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Total)
+	assert.Len(t, result.Hits, 1)
+	query := server.query()
+	assert.Equal(t, "search", query.Get("action"))
+	assert.Equal(t, "from Person where name = 'O''Brien' and age > 18", query.Get("query"))
+	assert.Equal(t, "5", query.Get("offset"))
+	assert.Equal(t, "10", query.Get("max_results"))
+}
+
+func TestIteratorsRequireStreaming(t *testing.T) {
+	// The curl client buffers the response, so iterators are rejected before a request is made
+	cache := v13.New(New(Config{}, nil)).Cache("test")
+	_, err := cache.Keys(0)
+	assert.EqualError(t, err, "iterating cache keys requires a HttpClient that streams the response body")
+	_, err = cache.Entries(0, false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,177 @@
+package native
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strings"
+	"sync"
+)
+
+// digestAuth computes RFC 7616 Authorization headers, falling back to Basic authentication if the server does not
+// offer a Digest challenge
+type digestAuth struct {
+	username string
+	password string
+
+	mu        sync.Mutex
+	basic     bool
+	realm     string
+	nonce     string
+	opaque    string
+	algorithm string
+	qop       string
+	nc        int
+}
+
+// challenge updates the state of the digestAuth with the WWW-Authenticate header received in a 401 response
+func (d *digestAuth) challenge(header string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	parts := strings.SplitN(strings.TrimSpace(header), " ", 2)
+	scheme := strings.ToLower(parts[0])
+	switch scheme {
+	case "basic":
+		d.basic = true
+		return nil
+	case "digest":
+		if len(parts) < 2 {
+			return fmt.Errorf("malformed Digest challenge '%s'", header)
+		}
+	default:
+		return fmt.Errorf("unsupported authentication scheme '%s'", parts[0])
+	}
+
+	params := parseParams(parts[1])
+	d.basic = false
+	d.realm = params["realm"]
+	d.nonce = params["nonce"]
+	d.opaque = params["opaque"]
+	d.algorithm = params["algorithm"]
+	d.qop = ""
+	d.nc = 0
+
+	if d.nonce == "" {
+		return fmt.Errorf("digest challenge does not contain a nonce '%s'", header)
+	}
+
+	if qop, ok := params["qop"]; ok {
+		for _, q := range strings.Split(qop, ",") {
+			if strings.TrimSpace(q) == "auth" {
+				d.qop = "auth"
+			}
+		}
+		if d.qop == "" {
+			return fmt.Errorf("unsupported digest qop '%s'", qop)
+		}
+	}
+	if d.hash() == nil {
+		return fmt.Errorf("unsupported digest algorithm '%s'", d.algorithm)
+	}
+	return nil
+}
+
+// authorization returns the Authorization header for the given request. False is returned if no challenge has been received yet.
+func (d *digestAuth) authorization(method, uri string) (string, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.basic {
+		credentials := base64.StdEncoding.EncodeToString([]byte(d.username + ":" + d.password))
+		return "Basic " + credentials, true
+	}
+
+	if d.nonce == "" {
+		return "", false
+	}
+
+	d.nc++
+	nc := fmt.Sprintf("%08x", d.nc)
+	cnonce := cnonce()
+
+	ha1 := d.hex(fmt.Sprintf("%s:%s:%s", d.username, d.realm, d.password))
+	if strings.HasSuffix(strings.ToLower(d.algorithm), "-sess") {
+		ha1 = d.hex(fmt.Sprintf("%s:%s:%s", ha1, d.nonce, cnonce))
+	}
+	ha2 := d.hex(fmt.Sprintf("%s:%s", method, uri))
+
+	var response string
+	if d.qop == "" {
+		response = d.hex(fmt.Sprintf("%s:%s:%s", ha1, d.nonce, ha2))
+	} else {
+		response = d.hex(fmt.Sprintf("%s:%s:%s:%s:%s:%s", ha1, d.nonce, nc, cnonce, d.qop, ha2))
+	}
+
+	b := new(strings.Builder)
+	fmt.Fprintf(b, `Digest username="%s", realm="%s", nonce="%s", uri="%s", response="%s"`, d.username, d.realm, d.nonce, uri, response)
+	if d.opaque != "" {
+		fmt.Fprintf(b, `, opaque="%s"`, d.opaque)
+	}
+	if d.qop != "" {
+		fmt.Fprintf(b, `, qop=%s, nc=%s, cnonce="%s"`, d.qop, nc, cnonce)
+	}
+	if d.algorithm != "" {
+		fmt.Fprintf(b, `, algorithm=%s`, d.algorithm)
+	}
+	return b.String(), true
+}
+
+func (d *digestAuth) hash() hash.Hash {
+	switch strings.ToUpper(strings.TrimSuffix(strings.ToLower(d.algorithm), "-sess")) {
+	case "", "MD5":
+		return md5.New()
+	case "SHA-256":
+		return sha256.New()
+	default:
+		return nil
+	}
+}
+
+func (d *digestAuth) hex(s string) string {
+	h := d.hash()
+	h.Write([]byte(s))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// parseParams parses the comma separated key=value pairs of a challenge, honouring quoted values that contain commas
+func parseParams(s string) map[string]string {
+	params := make(map[string]string)
+	for len(s) > 0 {
+		s = strings.TrimLeft(s, " ,")
+		eq := strings.IndexByte(s, '=')
+		if eq < 0 {
+			break
+		}
+		key := strings.ToLower(strings.TrimSpace(s[:eq]))
+		s = s[eq+1:]
+
+		var value string
+		if strings.HasPrefix(s, `"`) {
+			end := strings.IndexByte(s[1:], '"')
+			if end < 0 {
+				value, s = s[1:], ""
+			} else {
+				value, s = s[1:end+1], s[end+2:]
+			}
+		} else if comma := strings.IndexByte(s, ','); comma < 0 {
+			value, s = s, ""
+		} else {
+			value, s = s[:comma], s[comma+1:]
+		}
+		params[key] = strings.TrimSpace(value)
+	}
+	return params
+}
+
+func cnonce() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
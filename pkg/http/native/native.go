@@ -0,0 +1,215 @@
+// Package native provides a http implementation that utilises the net/http package to communicate directly with a server
+package native
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultTimeout = 30 * time.Second
+
+	maxIdleConnsPerHost = 10
+	idleConnTimeout     = 90 * time.Second
+)
+
+// defaultTransport is shared by all Clients that do not require a custom TLS configuration so that connections
+// to the same host are pooled across Client instances
+var defaultTransport = newTransport(nil)
+
+type Credentials struct {
+	Username string
+	Password string
+}
+
+type Config struct {
+	Credentials *Credentials
+	Host        string
+	Port        int
+	Protocol    string
+	TLSConfig   *tls.Config
+	Timeout     time.Duration
+}
+
+// Client executes requests against a single host. Response bodies are not buffered, so callers can stream large
+// responses by reading the body incrementally. Config.Timeout bounds the time until the response headers are
+// received, not the time taken to consume the body.
+type Client struct {
+	Config  Config
+	client  *http.Client
+	auth    *digestAuth
+	timeout time.Duration
+}
+
+func New(c Config) *Client {
+	transport := defaultTransport
+	if c.TLSConfig != nil {
+		transport = newTransport(c.TLSConfig)
+	}
+	return newClient(c, transport)
+}
+
+func newClient(c Config, transport *http.Transport) *Client {
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	client := &Client{
+		Config: c,
+		client: &http.Client{
+			Transport: transport,
+		},
+		timeout: timeout,
+	}
+	if c.Credentials != nil {
+		client.auth = &digestAuth{
+			username: c.Credentials.Username,
+			password: c.Credentials.Password,
+		}
+	}
+	return client
+}
+
+func newTransport(tlsConfig *tls.Config) *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig:     tlsConfig,
+		MaxIdleConnsPerHost: maxIdleConnsPerHost,
+		IdleConnTimeout:     idleConnTimeout,
+	}
+}
+
+// CloneForHost returns a new Client that shares the connection pool and credentials of the original, but targets the provided host
+func (c *Client) CloneForHost(host string) *Client {
+	config := c.Config
+	config.Host = host
+	return newClient(config, c.client.Transport.(*http.Transport))
+}
+
+// Streaming returns true as response bodies are read from the underlying connection
+func (c *Client) Streaming() bool {
+	return true
+}
+
+func (c *Client) Head(path string, headers map[string]string) (*http.Response, error) {
+	return c.exec(http.MethodHead, path, "", headers)
+}
+
+func (c *Client) Get(path string, headers map[string]string) (*http.Response, error) {
+	return c.exec(http.MethodGet, path, "", headers)
+}
+
+func (c *Client) Post(path, payload string, headers map[string]string) (*http.Response, error) {
+	return c.exec(http.MethodPost, path, payload, headers)
+}
+
+func (c *Client) Put(path, payload string, headers map[string]string) (*http.Response, error) {
+	return c.exec(http.MethodPut, path, payload, headers)
+}
+
+func (c *Client) Delete(path string, headers map[string]string) (*http.Response, error) {
+	return c.exec(http.MethodDelete, path, "", headers)
+}
+
+func (c *Client) exec(method, path, payload string, headers map[string]string) (*http.Response, error) {
+	httpURL := fmt.Sprintf("%s://%s/%s", c.Config.Protocol, net.JoinHostPort(c.Config.Host, fmt.Sprint(c.Config.Port)), path)
+
+	req, err := newRequest(method, httpURL, payload, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.auth == nil {
+		return c.do(req)
+	}
+
+	// Reuse the last digest challenge where possible to avoid an additional round trip for every request
+	if authorization, ok := c.auth.authorization(method, req.URL.RequestURI()); ok {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	rsp, err := c.do(req)
+	if err != nil || rsp.StatusCode != http.StatusUnauthorized {
+		return rsp, err
+	}
+
+	challenge := rsp.Header.Get("WWW-Authenticate")
+	if err := drainBody(rsp); err != nil {
+		return nil, err
+	}
+	if err := c.auth.challenge(challenge); err != nil {
+		return nil, err
+	}
+
+	req, err = newRequest(method, httpURL, payload, headers)
+	if err != nil {
+		return nil, err
+	}
+	authorization, _ := c.auth.authorization(method, req.URL.RequestURI())
+	req.Header.Set("Authorization", authorization)
+	return c.do(req)
+}
+
+// do executes the request, cancelling it if the response headers are not received within the configured timeout.
+// The request context is only released once the response body is closed so that the body can be streamed.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithCancel(req.Context())
+	timer := time.AfterFunc(c.timeout, cancel)
+	rsp, err := c.client.Do(req.WithContext(ctx))
+	if err != nil || !timer.Stop() {
+		cancel()
+		if err == nil {
+			_ = rsp.Body.Close()
+			err = fmt.Errorf("timeout awaiting response from '%s'", req.URL)
+		}
+		return nil, err
+	}
+	rsp.Body = &cancelBody{ReadCloser: rsp.Body, cancel: cancel}
+	return rsp, nil
+}
+
+// cancelBody releases the context of the request when the response body is closed
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+func newRequest(method, httpURL, payload string, headers map[string]string) (*http.Request, error) {
+	var body io.Reader
+	if payload != "" {
+		body = strings.NewReader(payload)
+	}
+	req, err := http.NewRequest(method, httpURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s request for '%s': %w", method, httpURL, err)
+	}
+	for header, value := range headers {
+		req.Header.Set(header, value)
+	}
+	return req, nil
+}
+
+// drainBody consumes and closes the response body so that the underlying connection can be returned to the pool
+func drainBody(rsp *http.Response) error {
+	if _, err := io.Copy(ioutil.Discard, rsp.Body); err != nil {
+		_ = rsp.Body.Close()
+		return err
+	}
+	return rsp.Body.Close()
+}
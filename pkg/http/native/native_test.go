@@ -0,0 +1,125 @@
+package native
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	realm    = "admin"
+	nonce    = "dcd98b7102dd2f0e8b11d0f600bfb0c093"
+	username = "operator"
+	password = "secret"
+)
+
+func md5Hex(s string) string {
+	h := md5.Sum([]byte(s))
+	return hex.EncodeToString(h[:])
+}
+
+func digestServer(challenges *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params := map[string]string{}
+		if auth := r.Header.Get("Authorization"); len(auth) > 7 {
+			params = parseParams(auth[7:])
+		}
+		ha1 := md5Hex(fmt.Sprintf("%s:%s:%s", username, realm, password))
+		ha2 := md5Hex(fmt.Sprintf("%s:%s", r.Method, r.URL.RequestURI()))
+		expected := md5Hex(fmt.Sprintf("%s:%s:%s:%s:%s:%s", ha1, nonce, params["nc"], params["cnonce"], params["qop"], ha2))
+		if params["response"] != expected {
+			*challenges++
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Digest realm="%s", domain="/", nonce="%s", qop="auth", algorithm=MD5`, realm, nonce))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}))
+}
+
+func clientFor(t *testing.T, server *httptest.Server, credentials *Credentials) *Client {
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	assert.NoError(t, err)
+	portNum, err := strconv.Atoi(port)
+	assert.NoError(t, err)
+	return New(Config{
+		Credentials: credentials,
+		Host:        host,
+		Port:        portNum,
+		Protocol:    "http",
+	})
+}
+
+func TestDigestAuthentication(t *testing.T) {
+	challenges := 0
+	server := digestServer(&challenges)
+	defer server.Close()
+
+	client := clientFor(t, server, &Credentials{Username: username, Password: password})
+	for i := 0; i < 3; i++ {
+		payload := fmt.Sprintf(`{"value": "it's %d"}`, i)
+		rsp, err := client.Post("rest/v2/caches/test?action=put", payload, map[string]string{"Content-Type": "application/json"})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rsp.StatusCode)
+		body, err := ioutil.ReadAll(rsp.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, rsp.Body.Close())
+		assert.Equal(t, payload, string(body))
+	}
+	// The challenge should only be issued once and reused for subsequent requests
+	assert.Equal(t, 1, challenges)
+}
+
+func TestInvalidCredentials(t *testing.T) {
+	challenges := 0
+	server := digestServer(&challenges)
+	defer server.Close()
+
+	client := clientFor(t, server, &Credentials{Username: username, Password: "invalid"})
+	rsp, err := client.Get("rest/v2/cache-managers/default", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rsp.StatusCode)
+	assert.NoError(t, rsp.Body.Close())
+}
+
+func TestTimeoutDoesNotApplyToBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("["))
+		w.(http.Flusher).Flush()
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte("]"))
+	}))
+	defer server.Close()
+
+	client := clientFor(t, server, nil)
+	client.timeout = 100 * time.Millisecond
+	rsp, err := client.Get("rest/v2/caches/test?action=keys", nil)
+	assert.NoError(t, err)
+	body, err := ioutil.ReadAll(rsp.Body)
+	assert.NoError(t, err)
+	assert.NoError(t, rsp.Body.Close())
+	assert.Equal(t, "[]", string(body))
+}
+
+func TestTimeoutAwaitingHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := clientFor(t, server, nil)
+	client.timeout = 50 * time.Millisecond
+	_, err := client.Get("rest/v2/caches/test?action=keys", nil)
+	assert.Error(t, err)
+}
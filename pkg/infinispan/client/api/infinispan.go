@@ -2,7 +2,9 @@
 package api
 
 import (
-	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/infinispan/infinispan-operator/pkg/mime"
 )
@@ -12,15 +14,20 @@ type Infinispan interface {
 	Cache(name string) Cache
 	Caches() Caches
 	Container() Container
+	Counters() Counters
 	Logging() Logging
 	Metrics() Metrics
+	Schemas() Schemas
 	Server() Server
+	Tasks() Tasks
 }
 
 // Container interface contains all operations and sub-interfaces related to interactions with the Infinispan cache-container
 type Container interface {
 	Info() (*ContainerInfo, error)
 	Backups() Backups
+	DisableRebalancing() error
+	EnableRebalancing() error
 	HealthStatus() (HealthStatus, error)
 	Members() ([]string, error)
 	Restores() Restores
@@ -31,30 +38,82 @@ type Container interface {
 
 // Backups contains all operations required for container Backup operations
 type Backups interface {
+	// Cancel removes the backup. The server stops a backup that is in progress before removing it, so callers must
+	// wait for Status to no longer report StatusRunning
+	Cancel(name string) error
 	Create(name string, config *BackupConfig) error
 	Status(name string) (Status, error)
 }
 
 // Restores contains all operations required for container Restore operations
 type Restores interface {
+	// Cancel removes the restore. The server stops a restore that is in progress before removing it, so callers must
+	// wait for Status to no longer report StatusRunning
+	Cancel(name string) error
 	Create(name string, config *RestoreConfig) error
 	Status(name string) (Status, error)
 }
 
 // Cache contains all operations and sub-interfaces for manipulating a specific cache
 type Cache interface {
+	Availability() (CacheAvailability, error)
+	Clear() error
 	Config(contentType mime.MimeType) (string, error)
 	Create(config string, contentType mime.MimeType, flags ...string) error
 	CreateWithTemplate(templateName string) error
 	Delete() error
+	DisableRebalancing() error
+	EnableRebalancing() error
+	// Entries returns an iterator over the cache entries. An error is returned unless the underlying http.HttpClient
+	// implements http.StreamingHttpClient
+	Entries(batchSize int, metadata bool) (EntryIterator, error)
 	Exists() (bool, error)
 	Get(key string) (string, bool, error)
+	GetEntry(key string) (*Entry, bool, error)
+	Index() Index
+	// Keys returns an iterator over the cache keys. An error is returned unless the underlying http.HttpClient implements
+	// http.StreamingHttpClient
+	Keys(batchSize int) (KeyIterator, error)
+	// Put creates the entry, returning an error with status 409 if the key already exists
 	Put(key, value string, contentType mime.MimeType) error
+	PutIfAbsent(key, value string, contentType mime.MimeType, opts *EntryOptions) error
+	// PutWithOptions creates the entry or overwrites the existing value
+	PutWithOptions(key, value string, contentType mime.MimeType, opts *EntryOptions) error
+	Query(query string, offset, maxResults int) (*QueryResult, error)
+	Remove(key string) error
+	Replace(key, value string, contentType mime.MimeType, opts *EntryOptions) error
 	RollingUpgrade() RollingUpgrade
+	SetAvailability(availability CacheAvailability) error
 	Size() (int, error)
+	Stats() (*CacheStats, error)
+	Xsite() CacheXsite
 	UpdateConfig(config string, contentType mime.MimeType) error
 }
 
+// KeyIterator streams the keys of a cache. Next must be called before the first call to Key and the iterator must
+// be closed once it is no longer required. Err returns the error, if any, that caused Next to return false
+type KeyIterator interface {
+	Next() bool
+	Key() string
+	Err() error
+	Close() error
+}
+
+// EntryIterator streams the entries of a cache, following the same contract as KeyIterator
+type EntryIterator interface {
+	Next() bool
+	Entry() *Entry
+	Err() error
+	Close() error
+}
+
+// Index contains all operations for managing the indexes of a specific cache
+type Index interface {
+	Clear() error
+	Reindex() error
+	Stats() (*SearchStats, error)
+}
+
 // RollingUpgrade contains all operations for coordinating rolling upgrades on a specific cache
 type RollingUpgrade interface {
 	AddSource(config string, contentType mime.MimeType) error
@@ -67,6 +126,20 @@ type RollingUpgrade interface {
 type Caches interface {
 	ConvertConfiguration(config string, contentType, reqType mime.MimeType) (string, error)
 	Names() ([]string, error)
+	TemplateNames() ([]string, error)
+}
+
+// Counters contains all operations related to clustered counters
+type Counters interface {
+	AddAndGet(name string, delta int64) (int64, error)
+	CompareAndSet(name string, expect, update int64) (bool, error)
+	Config(name string) (*CounterConfig, error)
+	Create(name string, config *CounterConfig) error
+	Delete(name string) error
+	Get(name string) (int64, error)
+	IncrementAndGet(name string) (int64, error)
+	Names() ([]string, error)
+	Reset(name string) error
 }
 
 // Cluster contains all operations that are performed cluster-wide
@@ -83,19 +156,73 @@ type Logging interface {
 
 // Metrics contains all operations related to the /metrics endpoint
 type Metrics interface {
-	Get(postfix string) (buf *bytes.Buffer, err error)
+	// Get returns all of the samples exposed by the server
+	Get() ([]MetricSample, error)
+	// Query returns the samples with the given name whose labels contain all of the provided labels
+	Query(name string, labels map[string]string) ([]MetricSample, error)
+}
+
+// Schemas contains all operations related to Protobuf schemas
+type Schemas interface {
+	Delete(name string) error
+	Errors(name string) (*SchemaError, error)
+	Get(name string) (string, bool, error)
+	List() ([]SchemaInfo, error)
+	Register(name, schema string) error
+	Update(name, schema string) error
 }
 
 // Server contains all operations related to the server process
 type Server interface {
+	Info() (*ServerInfo, error)
+	// Report returns the gzipped tar archive of the server report generated by the node handling the request
+	Report() ([]byte, error)
 	Stop() error
+	// ThreadDump returns a thread dump of the node handling the request
+	ThreadDump() (string, error)
+}
+
+// Tasks contains all operations related to server tasks and scripts
+type Tasks interface {
+	Create(name, script string) error
+	Exec(name string, params map[string]string) (string, error)
+	List(taskType TaskType) ([]Task, error)
 }
 
 // Xsite contains all Xsite replated operations
 type Xsite interface {
+	BringOnline(site string) error
+	CancelPushState(site string) error
 	PushAllState() error
+	PushState(site string) error
+	Status() (map[string]SiteStatus, error)
+	TakeOffline(site string) error
 }
 
+// CacheXsite contains all Xsite related operations for a specific cache
+type CacheXsite interface {
+	BringOnline(site string) error
+	CancelPushState(site string) error
+	ClearPushStateStatus() error
+	PushState(site string) error
+	PushStateStatus() (map[string]PushStateStatus, error)
+	Status() (map[string]SiteState, error)
+	TakeOffline(site string) error
+	TakeOfflineConfig(site string) (*TakeOfflineConfig, error)
+	UpdateTakeOfflineConfig(site string, config *TakeOfflineConfig) error
+}
+
+// Errors returned by entry operations when the server rejects a request with a status code that has a specific meaning
+// for the operation. The returned errors wrap these values, so they should be compared with errors.Is
+var (
+	// ErrEntryNotFound the entry, or the cache containing it, does not exist
+	ErrEntryNotFound = fmt.Errorf("entry not found")
+	// ErrEntryExists the entry already exists and the operation requires it to be absent
+	ErrEntryExists = fmt.Errorf("entry already exists")
+	// ErrPreconditionFailed the ETag of the entry does not match the ETag provided with the operation
+	ErrPreconditionFailed = fmt.Errorf("entry precondition failed")
+)
+
 // HealthStatus indicated the possible statuses of the Infinispan server
 type HealthStatus string
 
@@ -106,6 +233,14 @@ const (
 	HealthStatusFailed            HealthStatus = "FAILED"
 )
 
+// CacheAvailability indicates whether a cache is available, or has entered degraded mode following a network partition
+type CacheAvailability string
+
+const (
+	CacheAvailabilityAvailable CacheAvailability = "AVAILABLE"
+	CacheAvailabilityDegraded  CacheAvailability = "DEGRADED_MODE"
+)
+
 type Status string
 
 const (
@@ -145,6 +280,220 @@ type BackupRestoreResources struct {
 }
 
 type ContainerInfo struct {
-	Coordinator bool           `json:"coordinator"`
-	SitesView   *[]interface{} `json:"sites_view,omitempty"`
+	Coordinator        bool           `json:"coordinator"`
+	RebalancingEnabled bool           `json:"rebalancing_enabled"`
+	SitesView          *[]interface{} `json:"sites_view,omitempty"`
+}
+
+type ServerInfo struct {
+	Version string `json:"version"`
+}
+
+type CounterType string
+
+const (
+	CounterTypeStrong CounterType = "strong-counter"
+	CounterTypeWeak   CounterType = "weak-counter"
+)
+
+type CounterStorage string
+
+const (
+	CounterStorageVolatile   CounterStorage = "VOLATILE"
+	CounterStoragePersistent CounterStorage = "PERSISTENT"
+)
+
+// CounterConfig the definition of a counter. UpperBound and LowerBound are only applicable to strong counters, whereas
+// ConcurrencyLevel is only applicable to weak counters
+type CounterConfig struct {
+	Type             CounterType    `json:"-"`
+	InitialValue     int64          `json:"initial-value"`
+	Storage          CounterStorage `json:"storage,omitempty"`
+	UpperBound       *int64         `json:"upper-bound,omitempty"`
+	LowerBound       *int64         `json:"lower-bound,omitempty"`
+	ConcurrencyLevel *int32         `json:"concurrency-level,omitempty"`
+}
+
+// SchemaInfo the name of a registered schema and its validation error, if any
+type SchemaInfo struct {
+	Name  string       `json:"name"`
+	Error *SchemaError `json:"error,omitempty"`
+}
+
+type SchemaError struct {
+	Message string `json:"message"`
+	Cause   string `json:"cause"`
+}
+
+type TaskType string
+
+const (
+	TaskTypeAll  TaskType = ""
+	TaskTypeUser TaskType = "user"
+)
+
+type Task struct {
+	Name          string   `json:"name"`
+	Type          string   `json:"type"`
+	Parameters    []string `json:"parameters,omitempty"`
+	ExecutionMode string   `json:"execution_mode,omitempty"`
+	AllowedRole   string   `json:"allowed_role,omitempty"`
+}
+
+// QueryResult the hits returned by an Ickle query and the total number of matching entries. TotalExact is false if
+// the server only provides a lower bound for Total
+type QueryResult struct {
+	Total      int
+	TotalExact bool
+	Hits       []QueryHit
+}
+
+// QueryHit a single entry, or projection, matched by an Ickle query. Hit contains the JSON representation of the
+// matched value and can be unmarshalled into the corresponding type with json.Unmarshal
+type QueryHit struct {
+	Hit json.RawMessage `json:"hit"`
+}
+
+// SearchStats the query and index statistics of a cache
+type SearchStats struct {
+	Query QueryStats `json:"query"`
+	Index IndexStats `json:"index"`
+}
+
+type QueryStats struct {
+	IndexedLocal       QueryTypeStats `json:"indexed_local"`
+	IndexedDistributed QueryTypeStats `json:"indexed_distributed"`
+	Hybrid             QueryTypeStats `json:"hybrid"`
+	NonIndexed         QueryTypeStats `json:"non_indexed"`
+	EntityLoad         QueryTypeStats `json:"entity_load"`
+}
+
+// QueryTypeStats the execution statistics of a single query type. Times are in nanoseconds
+type QueryTypeStats struct {
+	Count   int64   `json:"count"`
+	Average float64 `json:"average"`
+	Max     int64   `json:"max"`
+	Slowest string  `json:"slowest,omitempty"`
+}
+
+type IndexStats struct {
+	Types      map[string]IndexTypeStats `json:"types"`
+	Reindexing bool                      `json:"reindexing"`
+}
+
+// IndexTypeStats the number of indexed entities of a given type and the size of the index in bytes
+type IndexTypeStats struct {
+	Count int64 `json:"count"`
+	Size  int64 `json:"size"`
+}
+
+// Entry a cache entry returned by Cache.Entries or Cache.GetEntry. The metadata fields are only populated if metadata was
+// requested, with -1 indicating that the entry has no lifespan or maxIdle. Times are milliseconds since the epoch and
+// Version is only present on caches that version their entries. ETag is only populated by Cache.GetEntry
+type Entry struct {
+	Key                string `json:"-"`
+	Value              string `json:"-"`
+	ETag               string `json:"-"`
+	TimeToLiveSeconds  int64  `json:"timeToLiveSeconds"`
+	MaxIdleTimeSeconds int64  `json:"maxIdleTimeSeconds"`
+	Created            int64  `json:"created"`
+	LastUsed           int64  `json:"lastUsed"`
+	ExpireTime         int64  `json:"expireTime"`
+	Version            *int64 `json:"version,omitempty"`
+}
+
+// EntryOptions the optional parameters used when writing an entry. A zero Lifespan or MaxIdle uses the cache's default,
+// whereas a negative value means that the entry never expires. If ETag is set, the entry is only written if its
+// current ETag matches
+type EntryOptions struct {
+	Lifespan time.Duration
+	MaxIdle  time.Duration
+	ETag     string
+}
+
+// CacheStats the statistics of a cache. The statistics are only collected if enabled in the cache configuration,
+// otherwise the counters are zero or -1. Average times are in nanoseconds and memory is in bytes
+type CacheStats struct {
+	Entries                int64 `json:"current_number_of_entries"`
+	EntriesInMemory        int64 `json:"current_number_of_entries_in_memory"`
+	Stores                 int64 `json:"stores"`
+	Retrievals             int64 `json:"retrievals"`
+	Hits                   int64 `json:"hits"`
+	Misses                 int64 `json:"misses"`
+	RemoveHits             int64 `json:"remove_hits"`
+	RemoveMisses           int64 `json:"remove_misses"`
+	Evictions              int64 `json:"evictions"`
+	DataMemoryUsed         int64 `json:"data_memory_used"`
+	OffHeapMemoryUsed      int64 `json:"off_heap_memory_used"`
+	AverageReadTimeNanos   int64 `json:"average_read_time_nanos"`
+	AverageWriteTimeNanos  int64 `json:"average_write_time_nanos"`
+	AverageRemoveTimeNanos int64 `json:"average_remove_time_nanos"`
+	// RebalancingEnabled whether rebalancing is enabled for the cache
+	RebalancingEnabled bool `json:"-"`
+	// RehashInProgress whether the cache's data is currently being rebalanced across the cluster
+	RehashInProgress bool `json:"-"`
+}
+
+// SiteState the state of a backup site. A site is mixed if it's online for some caches, or nodes, and offline for others
+type SiteState string
+
+const (
+	SiteStateOnline  SiteState = "online"
+	SiteStateOffline SiteState = "offline"
+	SiteStateMixed   SiteState = "mixed"
+)
+
+// SiteStatus the state of a backup site across all caches. Online, Offline and Mixed contain the names of the caches in
+// each state and are only populated when the site is mixed
+type SiteStatus struct {
+	Status  SiteState `json:"status"`
+	Online  []string  `json:"online,omitempty"`
+	Offline []string  `json:"offline,omitempty"`
+	Mixed   []string  `json:"mixed,omitempty"`
+}
+
+// PushStateStatus the status of a state transfer to a backup site
+type PushStateStatus string
+
+const (
+	PushStateStatusSending    PushStateStatus = "SENDING"
+	PushStateStatusOK         PushStateStatus = "OK"
+	PushStateStatusError      PushStateStatus = "ERROR"
+	PushStateStatusCancelling PushStateStatus = "CANCELLING"
+)
+
+// TakeOfflineConfig determines when a backup site is automatically taken offline. A site is taken offline after
+// AfterFailures consecutive failed requests, once at least MinWait milliseconds have passed since the first failure.
+// A value of zero or less disables the respective condition
+type TakeOfflineConfig struct {
+	AfterFailures int32 `json:"after_failures"`
+	MinWait       int64 `json:"min_wait"`
+}
+
+type MetricType string
+
+const (
+	MetricTypeCounter   MetricType = "counter"
+	MetricTypeGauge     MetricType = "gauge"
+	MetricTypeHistogram MetricType = "histogram"
+	MetricTypeSummary   MetricType = "summary"
+	MetricTypeUnknown   MetricType = "unknown"
+)
+
+// MetricSample is a single sample exposed via the Prometheus or OpenMetrics exposition format
+type MetricSample struct {
+	Name   string
+	Type   MetricType
+	Labels map[string]string
+	Value  float64
+}
+
+// Matches returns true if the sample contains all of the provided labels
+func (s *MetricSample) Matches(labels map[string]string) bool {
+	for k, v := range labels {
+		if value, ok := s.Labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
 }
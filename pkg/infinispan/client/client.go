@@ -1,11 +1,17 @@
 /*
 Package client provides a client api that should be used to query and manipulate Infinispan server(s) using HTTP.
 
-A new client should be created by calling the New factory method:
+If the server version is already known, for example because it has been derived from the server image or recorded in the
+Infinispan status, a new client should be created by calling the NewForVersion factory method:
 
 	var httpClient http.HttpClient
 	...
-	ispnClient := client.New(httpClient)
+	ispnClient, err := client.NewForVersion(&version.Version{Major: 14}, httpClient)
+
+Otherwise the New factory method can be used, which detects the server version via an additional request to the server info
+endpoint and returns the matching implementation:
+
+	ispnClient := client.New(httpClient, logger)
 
 The api package defines all types and interfaces required to interact with the Infinispan server(s).
 
@@ -42,12 +48,56 @@ For example, if v14 can reuse a new `api.Cache` implementation but requires a ne
 package client
 
 import (
+	"fmt"
+
+	"github.com/go-logr/logr"
 	"github.com/infinispan/infinispan-operator/pkg/http"
 	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
 	v13 "github.com/infinispan/infinispan-operator/pkg/infinispan/client/v13"
+	v14 "github.com/infinispan/infinispan-operator/pkg/infinispan/client/v14"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/version"
 )
 
-// New Factory to obtain Infinispan implementation
-func New(client http.HttpClient) api.Infinispan {
-	return v13.New(client)
+// New Factory to obtain the Infinispan implementation matching the version reported by the server.
+// The v13 implementation is returned, and the fallback logged, if the server version cannot be determined.
+func New(client http.HttpClient, log logr.Logger) api.Infinispan {
+	v, err := ServerVersion(client)
+	if err != nil {
+		log.Error(err, "unable to detect the server version, falling back to the 13.x client")
+		return v13.New(client)
+	}
+	return NewForVersionOrDefault(v, client, log)
+}
+
+// NewForVersionOrDefault Factory to obtain the Infinispan implementation for the provided server version.
+// The v13 implementation is returned, and the fallback logged, if the server version is not supported.
+func NewForVersionOrDefault(v *version.Version, client http.HttpClient, log logr.Logger) api.Infinispan {
+	ispn, err := NewForVersion(v, client)
+	if err != nil {
+		log.Info("unsupported server version, falling back to the 13.x client", "version", v.String())
+		return v13.New(client)
+	}
+	return ispn
+}
+
+// NewForVersion Factory to obtain the Infinispan implementation for the provided server version
+func NewForVersion(v *version.Version, client http.HttpClient) (api.Infinispan, error) {
+	switch v.Major {
+	case 13:
+		return v13.New(client), nil
+	case 14:
+		return v14.New(client), nil
+	default:
+		return nil, version.UnknownError(v)
+	}
+}
+
+// ServerVersion retrieves the version of the Infinispan server from the server info endpoint
+func ServerVersion(client http.HttpClient) (*version.Version, error) {
+	// The server info endpoint is unchanged across all supported versions
+	info, err := v13.New(client).Server().Info()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve server version: %w", err)
+	}
+	return version.FromString(info.Version)
 }
@@ -27,6 +27,11 @@ type restores struct {
 	httpClient.HttpClient
 }
 
+func (b backups) Cancel(name string) error {
+	url := fmt.Sprintf("%s/%s", BackupPath, name)
+	return cancel(url, name, "backup", b)
+}
+
 func (b backups) Create(name string, config *api.BackupConfig) (err error) {
 	url := fmt.Sprintf("%s/%s", BackupPath, name)
 	return create(url, name, "backup", config, b)
@@ -37,6 +42,11 @@ func (b backups) Status(name string) (api.Status, error) {
 	return status(url, name, "Backup", b)
 }
 
+func (r restores) Cancel(name string) error {
+	url := fmt.Sprintf("%s/%s", RestorePath, name)
+	return cancel(url, name, "restore", r)
+}
+
 func (r restores) Create(name string, config *api.RestoreConfig) (err error) {
 	url := fmt.Sprintf("%s/%s", RestorePath, name)
 	return create(url, name, "restore", config, r)
@@ -69,6 +79,20 @@ func create(url, name, op string, config interface{}, client httpClient.HttpClie
 	return httpClient.ValidateResponse(rsp, err, "creating "+op, http.StatusAccepted)
 }
 
+// cancel removes the operation from the server. The server responds with 202 Accepted if the operation is in progress
+// and removes it once it has stopped, a missing operation is not considered an error
+func cancel(url, name, op string, client httpClient.HttpClient) (err error) {
+	if err := validator.Var(name, "required"); err != nil {
+		return err
+	}
+
+	rsp, err := client.Delete(url, nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	return httpClient.ValidateResponse(rsp, err, "cancelling "+op, http.StatusAccepted, http.StatusNoContent, http.StatusNotFound)
+}
+
 func status(url, name, op string, client httpClient.HttpClient) (api.Status, error) {
 	if err := validator.Var(name, "required"); err != nil {
 		return api.StatusUnknown, err
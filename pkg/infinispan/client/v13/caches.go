@@ -2,11 +2,14 @@ package v13
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	httpClient "github.com/infinispan/infinispan-operator/pkg/http"
 	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
@@ -32,6 +35,29 @@ func (c *cache) entryUrl(key string) string {
 	return fmt.Sprintf("%s/%s", c.url(), key)
 }
 
+func (c *cache) Availability() (availability api.CacheAvailability, err error) {
+	rsp, err := c.HttpClient.Get(c.url()+"?action=get-availability", nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	if err = httpClient.ValidateResponse(rsp, err, "getting cache availability", http.StatusOK); err != nil {
+		return
+	}
+	body, err := readResponseBody(rsp)
+	if err != nil {
+		return
+	}
+	return api.CacheAvailability(strings.TrimSpace(body)), nil
+}
+
+func (c *cache) Clear() (err error) {
+	rsp, err := c.Post(c.url()+"?action=clear", "", nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	return entryError(httpClient.ValidateResponse(rsp, err, "clearing cache", http.StatusOK, http.StatusNoContent))
+}
+
 func (c *cache) Config(contentType mime.MimeType) (config string, err error) {
 	path := c.url() + "?action=config"
 	rsp, err := c.HttpClient.Get(path, nil)
@@ -85,6 +111,36 @@ func (c *cache) Delete() (err error) {
 	return
 }
 
+func (c *cache) DisableRebalancing() error {
+	return c.rebalancing("disable-rebalancing", "disabling cache rebalancing")
+}
+
+func (c *cache) EnableRebalancing() error {
+	return c.rebalancing("enable-rebalancing", "enabling cache rebalancing")
+}
+
+func (c *cache) rebalancing(action, entity string) (err error) {
+	rsp, err := c.Post(c.url()+"?action="+action, "", nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	return httpClient.ValidateResponse(rsp, err, entity, http.StatusOK, http.StatusNoContent)
+}
+
+func (c *cache) Entries(batchSize int, metadata bool) (api.EntryIterator, error) {
+	params := url.Values{}
+	params.Set("action", "entries")
+	params.Set("metadata", strconv.FormatBool(metadata))
+	if batchSize > 0 {
+		params.Set("batch", strconv.Itoa(batchSize))
+	}
+	stream, err := c.stream(params, "iterating cache entries")
+	if err != nil {
+		return nil, err
+	}
+	return &entryIterator{jsonArrayStream: stream}, nil
+}
+
 func (c *cache) Exists() (exist bool, err error) {
 	rsp, err := c.Head(c.url(), nil)
 	defer func() {
@@ -121,6 +177,54 @@ func (c *cache) Get(key string) (val string, exists bool, err error) {
 	return body, true, err
 }
 
+func (c *cache) Index() api.Index {
+	return &index{
+		cache:      c,
+		HttpClient: c.HttpClient,
+	}
+}
+
+func (c *cache) Keys(batchSize int) (api.KeyIterator, error) {
+	params := url.Values{}
+	params.Set("action", "keys")
+	if batchSize > 0 {
+		params.Set("batch", strconv.Itoa(batchSize))
+	}
+	stream, err := c.stream(params, "iterating cache keys")
+	if err != nil {
+		return nil, err
+	}
+	return &keyIterator{jsonArrayStream: stream}, nil
+}
+
+func (c *cache) GetEntry(key string) (entry *api.Entry, exists bool, err error) {
+	rsp, err := c.HttpClient.Get(c.entryUrl(key), nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	if err = httpClient.ValidateResponse(rsp, err, "getting cache entry", http.StatusOK, http.StatusNotFound); err != nil {
+		return
+	}
+	if rsp.StatusCode == http.StatusNotFound {
+		return
+	}
+	value, err := readResponseBody(rsp)
+	if err != nil {
+		return
+	}
+	entry = &api.Entry{
+		Key:                key,
+		Value:              value,
+		ETag:               rsp.Header.Get("ETag"),
+		TimeToLiveSeconds:  headerInt(rsp.Header, "timeToLiveSeconds"),
+		MaxIdleTimeSeconds: headerInt(rsp.Header, "maxIdleTimeSeconds"),
+		Created:            headerInt(rsp.Header, "created"),
+		LastUsed:           headerInt(rsp.Header, "lastUsed"),
+		ExpireTime:         -1,
+	}
+	return entry, true, nil
+}
+
 func (c *cache) Put(key, value string, contentType mime.MimeType) (err error) {
 	headers := map[string]string{
 		"Content-Type": string(contentType),
@@ -135,6 +239,31 @@ func (c *cache) Put(key, value string, contentType mime.MimeType) (err error) {
 	return nil
 }
 
+func (c *cache) PutIfAbsent(key, value string, contentType mime.MimeType, opts *api.EntryOptions) (err error) {
+	rsp, err := c.HttpClient.Post(c.entryUrl(key), value, entryHeaders(contentType, opts))
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	return entryError(httpClient.ValidateResponse(rsp, err, "putting absent cache entry", http.StatusOK, http.StatusNoContent))
+}
+
+func (c *cache) PutWithOptions(key, value string, contentType mime.MimeType, opts *api.EntryOptions) (err error) {
+	rsp, err := c.HttpClient.Put(c.entryUrl(key), value, entryHeaders(contentType, opts))
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	return entryError(httpClient.ValidateResponse(rsp, err, "putting cache entry", http.StatusOK, http.StatusNoContent))
+}
+
+func (c *cache) SetAvailability(availability api.CacheAvailability) (err error) {
+	path := fmt.Sprintf("%s?action=set-availability&availability=%s", c.url(), availability)
+	rsp, err := c.Post(path, "", nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	return httpClient.ValidateResponse(rsp, err, "setting cache availability", http.StatusOK, http.StatusNoContent)
+}
+
 func (c *cache) Size() (size int, err error) {
 	rsp, err := c.HttpClient.Get(c.url()+"?action=size", nil)
 	defer func() {
@@ -150,6 +279,101 @@ func (c *cache) Size() (size int, err error) {
 	return strconv.Atoi(body)
 }
 
+func (c *cache) Query(query string, offset, maxResults int) (result *api.QueryResult, err error) {
+	params := url.Values{}
+	params.Set("action", "search")
+	params.Set("query", query)
+	params.Set("offset", strconv.Itoa(offset))
+	params.Set("max_results", strconv.Itoa(maxResults))
+	rsp, err := c.HttpClient.Get(c.url()+"?"+params.Encode(), nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	if err = httpClient.ValidateResponse(rsp, err, "querying cache", http.StatusOK); err != nil {
+		return
+	}
+
+	// Newer servers report the total as hit_count, with hit_count_exact indicating whether it's an estimate
+	type queryResponse struct {
+		TotalResults  *int           `json:"total_results"`
+		HitCount      *int           `json:"hit_count"`
+		HitCountExact *bool          `json:"hit_count_exact"`
+		Hits          []api.QueryHit `json:"hits"`
+	}
+	body := &queryResponse{}
+	if err = json.NewDecoder(rsp.Body).Decode(body); err != nil {
+		return nil, fmt.Errorf("unable to decode: %w", err)
+	}
+
+	result = &api.QueryResult{
+		TotalExact: true,
+		Hits:       body.Hits,
+	}
+	if body.HitCount != nil {
+		result.Total = *body.HitCount
+	} else if body.TotalResults != nil {
+		result.Total = *body.TotalResults
+	}
+	if body.HitCountExact != nil {
+		result.TotalExact = *body.HitCountExact
+	}
+	return
+}
+
+func (c *cache) Remove(key string) (err error) {
+	rsp, err := c.HttpClient.Delete(c.entryUrl(key), nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	return entryError(httpClient.ValidateResponse(rsp, err, "removing cache entry", http.StatusOK, http.StatusNoContent))
+}
+
+// Replace updates the value of an existing entry. The REST API has no replace operation, so the update is made
+// conditional on the entry's current ETag, which is retrieved first if not provided, to prevent the entry being
+// recreated if it's removed concurrently
+func (c *cache) Replace(key, value string, contentType mime.MimeType, opts *api.EntryOptions) error {
+	replaceOpts := api.EntryOptions{}
+	if opts != nil {
+		replaceOpts = *opts
+	}
+	if replaceOpts.ETag == "" {
+		entry, exists, err := c.GetEntry(key)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("unable to replace cache entry '%s': %w", key, api.ErrEntryNotFound)
+		}
+		replaceOpts.ETag = entry.ETag
+	}
+	return c.PutWithOptions(key, value, contentType, &replaceOpts)
+}
+
+func (c *cache) Stats() (stats *api.CacheStats, err error) {
+	// The cache details contain the rebalancing state as well as the statistics returned by ?action=stats
+	rsp, err := c.HttpClient.Get(c.url(), nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	if err = httpClient.ValidateResponse(rsp, err, "getting cache stats", http.StatusOK); err != nil {
+		return
+	}
+
+	type cacheDetails struct {
+		Stats              api.CacheStats `json:"stats"`
+		RebalancingEnabled bool           `json:"rebalancing_enabled"`
+		RehashInProgress   bool           `json:"rehash_in_progress"`
+	}
+	details := &cacheDetails{}
+	if err = json.NewDecoder(rsp.Body).Decode(details); err != nil {
+		return nil, fmt.Errorf("unable to decode: %w", err)
+	}
+	stats = &details.Stats
+	stats.RebalancingEnabled = details.RebalancingEnabled
+	stats.RehashInProgress = details.RehashInProgress
+	return
+}
+
 func (c *cache) RollingUpgrade() api.RollingUpgrade {
 	return &rollingUpgrade{
 		cache:      c,
@@ -170,6 +394,13 @@ func (c *cache) UpdateConfig(config string, contentType mime.MimeType) (err erro
 	return
 }
 
+func (c *cache) Xsite() api.CacheXsite {
+	return &cacheXsite{
+		cache:      c,
+		HttpClient: c.HttpClient,
+	}
+}
+
 func (c *caches) ConvertConfiguration(config string, contentType mime.MimeType, reqType mime.MimeType) (transformed string, err error) {
 	path := CachesPath + "?action=convert"
 	headers := map[string]string{
@@ -203,6 +434,30 @@ func (c *caches) Names() (names []string, err error) {
 	return
 }
 
+// TemplateNames returns the names of all templates, including the templates provided by the server
+func (c *caches) TemplateNames() (names []string, err error) {
+	rsp, err := c.Get(TemplatesPath, nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+
+	if err = httpClient.ValidateResponse(rsp, err, "getting cache templates", http.StatusOK); err != nil {
+		return
+	}
+
+	var templates []struct {
+		Name string `json:"name"`
+	}
+	if err = json.NewDecoder(rsp.Body).Decode(&templates); err != nil {
+		return nil, fmt.Errorf("unable to decode: %w", err)
+	}
+	names = make([]string, len(templates))
+	for i, template := range templates {
+		names[i] = template.Name
+	}
+	return
+}
+
 func readResponseBody(rsp *http.Response) (string, error) {
 	responseBody, responseErr := ioutil.ReadAll(rsp.Body)
 	if responseErr != nil {
@@ -210,3 +465,57 @@ func readResponseBody(rsp *http.Response) (string, error) {
 	}
 	return string(responseBody), nil
 }
+
+// entryHeaders returns the headers required to write an entry with the provided content type and options
+func entryHeaders(contentType mime.MimeType, opts *api.EntryOptions) map[string]string {
+	headers := map[string]string{
+		"Content-Type": string(contentType),
+	}
+	if opts == nil {
+		return headers
+	}
+	if opts.Lifespan != 0 {
+		headers["timeToLiveSeconds"] = expirationSeconds(opts.Lifespan)
+	}
+	if opts.MaxIdle != 0 {
+		headers["maxIdleTimeSeconds"] = expirationSeconds(opts.MaxIdle)
+	}
+	if opts.ETag != "" {
+		headers["If-Match"] = opts.ETag
+	}
+	return headers
+}
+
+// expirationSeconds converts a lifespan or maxIdle duration to seconds, rounding up so that sub-second durations do
+// not disable expiration
+func expirationSeconds(d time.Duration) string {
+	if d < 0 {
+		return "-1"
+	}
+	return strconv.FormatInt(int64((d+time.Second-1)/time.Second), 10)
+}
+
+// entryError maps the status codes returned by entry operations to the corresponding api error
+func entryError(err error) error {
+	var httpErr *httpClient.HttpError
+	if !errors.As(err, &httpErr) {
+		return err
+	}
+	switch httpErr.Status {
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s", api.ErrEntryNotFound, httpErr.Message)
+	case http.StatusConflict:
+		return fmt.Errorf("%w: %s", api.ErrEntryExists, httpErr.Message)
+	case http.StatusPreconditionFailed:
+		return fmt.Errorf("%w: %s", api.ErrPreconditionFailed, httpErr.Message)
+	}
+	return err
+}
+
+func headerInt(header http.Header, key string) int64 {
+	i, err := strconv.ParseInt(header.Get(key), 10, 64)
+	if err != nil {
+		return -1
+	}
+	return i
+}
@@ -14,6 +14,7 @@ import (
 
 const (
 	CacheManagerPath = BasePath + "/cache-managers/default"
+	TemplatesPath    = CacheManagerPath + "/cache-configs/templates"
 	ContainerPath    = BasePath + "/container"
 	HealthPath       = CacheManagerPath + "/health"
 	HealthStatusPath = HealthPath + "/status"
@@ -39,6 +40,22 @@ func (c *container) Info() (info *api.ContainerInfo, err error) {
 	return
 }
 
+func (c *container) DisableRebalancing() error {
+	return c.rebalancing("disable-rebalancing", "disabling rebalancing")
+}
+
+func (c *container) EnableRebalancing() error {
+	return c.rebalancing("enable-rebalancing", "enabling rebalancing")
+}
+
+func (c *container) rebalancing(action, entity string) (err error) {
+	rsp, err := c.Post(CacheManagerPath+"?action="+action, "", nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	return httpClient.ValidateResponse(rsp, err, entity, http.StatusOK, http.StatusNoContent)
+}
+
 func (c *container) HealthStatus() (status api.HealthStatus, err error) {
 	rsp, err := c.Get(HealthStatusPath, nil)
 	defer func() {
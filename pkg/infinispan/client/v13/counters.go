@@ -0,0 +1,164 @@
+package v13
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	httpClient "github.com/infinispan/infinispan-operator/pkg/http"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	"github.com/infinispan/infinispan-operator/pkg/mime"
+)
+
+const CountersPath = BasePath + "/counters"
+
+type counters struct {
+	httpClient.HttpClient
+}
+
+func counterUrl(name string) string {
+	return fmt.Sprintf("%s/%s", CountersPath, name)
+}
+
+// AddAndGet adds the delta to the counter and returns the updated value. Weak counters do not return the updated value,
+// so it is retrieved via a subsequent Get
+func (c *counters) AddAndGet(name string, delta int64) (int64, error) {
+	return c.update(name, fmt.Sprintf("action=add&delta=%d", delta), "adding to counter")
+}
+
+func (c *counters) CompareAndSet(name string, expect, update int64) (set bool, err error) {
+	path := fmt.Sprintf("%s?action=compareAndSet&expect=%d&update=%d", counterUrl(name), expect, update)
+	rsp, err := c.Post(path, "", nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	if err = httpClient.ValidateResponse(rsp, err, "comparing and setting counter", http.StatusOK); err != nil {
+		return
+	}
+	body, err := readResponseBody(rsp)
+	if err != nil {
+		return
+	}
+	return strconv.ParseBool(strings.TrimSpace(body))
+}
+
+// Config returns the configuration of the counter or nil if the counter does not exist
+func (c *counters) Config(name string) (config *api.CounterConfig, err error) {
+	rsp, err := c.HttpClient.Get(counterUrl(name)+"/config", nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	if err = httpClient.ValidateResponse(rsp, err, "getting counter config", http.StatusOK, http.StatusNotFound); err != nil {
+		return
+	}
+	if rsp.StatusCode == http.StatusNotFound {
+		return
+	}
+
+	var configs map[api.CounterType]*api.CounterConfig
+	if err = json.NewDecoder(rsp.Body).Decode(&configs); err != nil {
+		return nil, fmt.Errorf("unable to decode: %w", err)
+	}
+	for counterType, counterConfig := range configs {
+		if counterConfig != nil && (counterType == api.CounterTypeStrong || counterType == api.CounterTypeWeak) {
+			counterConfig.Type = counterType
+			return counterConfig, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected counter configuration format for counter '%s'", name)
+}
+
+func (c *counters) Create(name string, config *api.CounterConfig) (err error) {
+	if config.Type != api.CounterTypeStrong && config.Type != api.CounterTypeWeak {
+		return fmt.Errorf("unknown counter type '%s'", config.Type)
+	}
+	payload, err := json.Marshal(map[api.CounterType]*api.CounterConfig{config.Type: config})
+	if err != nil {
+		return fmt.Errorf("unable to encode counter config: %w", err)
+	}
+
+	headers := map[string]string{
+		"Content-Type": string(mime.ApplicationJson),
+	}
+	rsp, err := c.Post(counterUrl(name), string(payload), headers)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	err = httpClient.ValidateResponse(rsp, err, "creating counter", http.StatusOK)
+	return
+}
+
+func (c *counters) Delete(name string) (err error) {
+	rsp, err := c.HttpClient.Delete(counterUrl(name), nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	err = httpClient.ValidateResponse(rsp, err, "deleting counter", http.StatusOK, http.StatusNoContent, http.StatusNotFound)
+	return
+}
+
+func (c *counters) Get(name string) (value int64, err error) {
+	rsp, err := c.HttpClient.Get(counterUrl(name), nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	if err = httpClient.ValidateResponse(rsp, err, "getting counter value", http.StatusOK); err != nil {
+		return
+	}
+	body, err := readResponseBody(rsp)
+	if err != nil {
+		return
+	}
+	return strconv.ParseInt(strings.TrimSpace(body), 10, 64)
+}
+
+// IncrementAndGet increments the counter and returns the updated value. Weak counters do not return the updated value,
+// so it is retrieved via a subsequent Get
+func (c *counters) IncrementAndGet(name string) (int64, error) {
+	return c.update(name, "action=increment", "incrementing counter")
+}
+
+func (c *counters) Names() (names []string, err error) {
+	rsp, err := c.HttpClient.Get(CountersPath, nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	if err = httpClient.ValidateResponse(rsp, err, "getting counters", http.StatusOK); err != nil {
+		return
+	}
+
+	if err = json.NewDecoder(rsp.Body).Decode(&names); err != nil {
+		return nil, fmt.Errorf("unable to decode: %w", err)
+	}
+	return
+}
+
+func (c *counters) Reset(name string) (err error) {
+	rsp, err := c.Post(counterUrl(name)+"?action=reset", "", nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	err = httpClient.ValidateResponse(rsp, err, "resetting counter", http.StatusOK, http.StatusNoContent)
+	return
+}
+
+func (c *counters) update(name, query, entity string) (value int64, err error) {
+	rsp, err := c.Post(fmt.Sprintf("%s?%s", counterUrl(name), query), "", nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	if err = httpClient.ValidateResponse(rsp, err, entity, http.StatusOK, http.StatusNoContent); err != nil {
+		return
+	}
+
+	if rsp.StatusCode == http.StatusNoContent {
+		return c.Get(name)
+	}
+	body, err := readResponseBody(rsp)
+	if err != nil {
+		return
+	}
+	return strconv.ParseInt(strings.TrimSpace(body), 10, 64)
+}
@@ -0,0 +1,51 @@
+package v13
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	httpClient "github.com/infinispan/infinispan-operator/pkg/http"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+)
+
+type index struct {
+	*cache
+	httpClient.HttpClient
+}
+
+func (i *index) url() string {
+	return i.cache.url() + "/search"
+}
+
+func (i *index) Clear() (err error) {
+	rsp, err := i.Post(i.url()+"/indexes?action=clear", "", nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	return httpClient.ValidateResponse(rsp, err, "clearing index", http.StatusOK, http.StatusNoContent)
+}
+
+func (i *index) Reindex() (err error) {
+	rsp, err := i.Post(i.url()+"/indexes?action=reindex", "", nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	return httpClient.ValidateResponse(rsp, err, "reindexing cache", http.StatusOK, http.StatusNoContent)
+}
+
+func (i *index) Stats() (stats *api.SearchStats, err error) {
+	rsp, err := i.HttpClient.Get(i.url()+"/stats", nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	if err = httpClient.ValidateResponse(rsp, err, "getting search stats", http.StatusOK); err != nil {
+		return
+	}
+
+	stats = &api.SearchStats{}
+	if err = json.NewDecoder(rsp.Body).Decode(stats); err != nil {
+		return nil, fmt.Errorf("unable to decode: %w", err)
+	}
+	return
+}
@@ -30,6 +30,10 @@ func (i *infinispan) Container() api.Container {
 	return &container{i.HttpClient}
 }
 
+func (i *infinispan) Counters() api.Counters {
+	return &counters{i.HttpClient}
+}
+
 func (i *infinispan) Logging() api.Logging {
 	return &logging{i.HttpClient}
 }
@@ -38,6 +42,14 @@ func (i *infinispan) Metrics() api.Metrics {
 	return &metrics{i.HttpClient}
 }
 
+func (i *infinispan) Schemas() api.Schemas {
+	return &schemas{i.HttpClient}
+}
+
 func (i *infinispan) Server() api.Server {
 	return &server{i.HttpClient}
 }
+
+func (i *infinispan) Tasks() api.Tasks {
+	return &tasks{i.HttpClient}
+}
@@ -0,0 +1,126 @@
+package v13
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	httpClient "github.com/infinispan/infinispan-operator/pkg/http"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+)
+
+// stream executes a request whose response is a JSON array and returns a jsonArrayStream that decodes the array
+// elements one at a time. An error is returned if the underlying HttpClient buffers the response body, as the entire
+// array would otherwise be held in memory.
+func (c *cache) stream(params url.Values, entity string) (*jsonArrayStream, error) {
+	if !httpClient.IsStreaming(c.HttpClient) {
+		return nil, fmt.Errorf("%s requires a HttpClient that streams the response body", entity)
+	}
+	rsp, err := c.HttpClient.Get(c.url()+"?"+params.Encode(), nil)
+	if err = httpClient.ValidateResponse(rsp, err, entity, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	stream := &jsonArrayStream{
+		rsp:     rsp,
+		decoder: json.NewDecoder(rsp.Body),
+	}
+	if token, err := stream.decoder.Token(); err != nil || token != json.Delim('[') {
+		_ = rsp.Body.Close()
+		if err == nil {
+			err = fmt.Errorf("expected JSON array, got '%v'", token)
+		}
+		return nil, fmt.Errorf("unable to decode: %w", err)
+	}
+	return stream, nil
+}
+
+// jsonArrayStream decodes the elements of a JSON array from a http.Response body
+type jsonArrayStream struct {
+	rsp     *http.Response
+	decoder *json.Decoder
+	done    bool
+	err     error
+}
+
+// next decodes the next array element into v, returning false once the end of the array is reached or an error occurs
+func (s *jsonArrayStream) next(v interface{}) bool {
+	if s.done {
+		return false
+	}
+	if !s.decoder.More() {
+		s.done = true
+		if _, err := s.decoder.Token(); err != nil {
+			s.err = fmt.Errorf("unable to decode: %w", err)
+		}
+		return false
+	}
+	if err := s.decoder.Decode(v); err != nil {
+		s.done = true
+		s.err = fmt.Errorf("unable to decode: %w", err)
+		return false
+	}
+	return true
+}
+
+func (s *jsonArrayStream) Err() error {
+	return s.err
+}
+
+func (s *jsonArrayStream) Close() error {
+	s.done = true
+	return s.rsp.Body.Close()
+}
+
+type keyIterator struct {
+	*jsonArrayStream
+	key string
+}
+
+func (i *keyIterator) Next() bool {
+	var key json.RawMessage
+	if !i.next(&key) {
+		return false
+	}
+	i.key = jsonString(key)
+	return true
+}
+
+func (i *keyIterator) Key() string {
+	return i.key
+}
+
+type entryIterator struct {
+	*jsonArrayStream
+	entry *api.Entry
+}
+
+func (i *entryIterator) Next() bool {
+	var raw struct {
+		api.Entry
+		Key   json.RawMessage `json:"key"`
+		Value json.RawMessage `json:"value"`
+	}
+	if !i.next(&raw) {
+		return false
+	}
+	entry := raw.Entry
+	entry.Key = jsonString(raw.Key)
+	entry.Value = jsonString(raw.Value)
+	i.entry = &entry
+	return true
+}
+
+func (i *entryIterator) Entry() *api.Entry {
+	return i.entry
+}
+
+// jsonString returns the value of a JSON string, or the raw JSON if the value is of another type
+func jsonString(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
@@ -1,12 +1,15 @@
 package v13
 
 import (
-	"bytes"
+	"bufio"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
 
 	httpClient "github.com/infinispan/infinispan-operator/pkg/http"
-	"github.com/infinispan/infinispan-operator/pkg/mime"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
 )
 
 const MetricsPath = "metrics"
@@ -15,27 +18,164 @@ type metrics struct {
 	httpClient.HttpClient
 }
 
-func (m *metrics) Get(postfix string) (buf *bytes.Buffer, err error) {
+func (m *metrics) Get() (samples []api.MetricSample, err error) {
 	headers := map[string]string{
-		"Acccept": string(mime.ApplicationJson),
+		"Accept": "text/plain",
 	}
 
-	path := fmt.Sprintf("%s/%s", MetricsPath, postfix)
-	rsp, err := m.HttpClient.Get(path, headers)
+	rsp, err := m.HttpClient.Get(MetricsPath, headers)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
 	if err = httpClient.ValidateResponse(rsp, err, "getting metrics", http.StatusOK); err != nil {
 		return
 	}
+	return ParseMetrics(rsp.Body)
+}
+
+func (m *metrics) Query(name string, labels map[string]string) ([]api.MetricSample, error) {
+	samples, err := m.Get()
+	if err != nil {
+		return nil, err
+	}
 
-	defer func() {
-		cerr := rsp.Body.Close()
-		if err == nil {
-			err = cerr
+	var matches []api.MetricSample
+	for _, s := range samples {
+		if s.Name == name && s.Matches(labels) {
+			matches = append(matches, s)
 		}
-	}()
+	}
+	return matches, nil
+}
 
-	buf = new(bytes.Buffer)
-	if _, err = buf.ReadFrom(rsp.Body); err != nil {
-		return
+// ParseMetrics parses samples in the Prometheus text or OpenMetrics exposition format. Timestamps and exemplars are ignored.
+func ParseMetrics(r io.Reader) ([]api.MetricSample, error) {
+	types := map[string]api.MetricType{}
+	var samples []api.MetricSample
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "#") {
+			// Only TYPE descriptors are of interest, HELP, UNIT, EOF and plain comments are ignored
+			fields := strings.Fields(line)
+			if len(fields) == 4 && fields[1] == "TYPE" {
+				types[fields[2]] = api.MetricType(strings.ToLower(fields[3]))
+			}
+			continue
+		}
+
+		sample, err := parseSample(line)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse metrics line %d: %w", lineNum, err)
+		}
+		sample.Type = metricType(sample.Name, types)
+		samples = append(samples, *sample)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read metrics: %w", err)
+	}
+	return samples, nil
+}
+
+func parseSample(line string) (*api.MetricSample, error) {
+	nameEnd := strings.IndexAny(line, "{ \t")
+	if nameEnd < 1 {
+		return nil, fmt.Errorf("malformed sample '%s'", line)
+	}
+
+	sample := &api.MetricSample{
+		Name:   line[:nameEnd],
+		Labels: map[string]string{},
+	}
+
+	rest := line[nameEnd:]
+	if rest[0] == '{' {
+		var err error
+		if rest, err = parseLabels(rest[1:], sample.Labels); err != nil {
+			return nil, fmt.Errorf("malformed labels in sample '%s': %w", line, err)
+		}
+	}
+
+	// Exemplars are separated from the value and the optional timestamp by a '#'
+	if i := strings.Index(rest, "#"); i > -1 {
+		rest = rest[:i]
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) < 1 || len(fields) > 2 {
+		return nil, fmt.Errorf("malformed sample '%s'", line)
+	}
+
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return nil, fmt.Errorf("malformed value in sample '%s': %w", line, err)
+	}
+	sample.Value = value
+	return sample, nil
+}
+
+// parseLabels adds the name="value" pairs up until the closing '}' to labels, returning the remainder of the line
+func parseLabels(s string, labels map[string]string) (string, error) {
+	for {
+		s = strings.TrimLeft(s, " \t,")
+		if s == "" {
+			return "", fmt.Errorf("missing closing '}'")
+		}
+		if s[0] == '}' {
+			return s[1:], nil
+		}
+
+		eq := strings.IndexByte(s, '=')
+		if eq < 1 || len(s) < eq+2 || s[eq+1] != '"' {
+			return "", fmt.Errorf("expected name=\"value\" pair")
+		}
+		name := strings.TrimSpace(s[:eq])
+		s = s[eq+2:]
+
+		var value strings.Builder
+		closed := false
+		for i := 0; i < len(s); i++ {
+			c := s[i]
+			if c == '\\' && i+1 < len(s) {
+				i++
+				switch s[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(s[i])
+				}
+				continue
+			}
+			if c == '"' {
+				s = s[i+1:]
+				closed = true
+				break
+			}
+			value.WriteByte(c)
+		}
+		if !closed {
+			return "", fmt.Errorf("unterminated value for label '%s'", name)
+		}
+		labels[name] = value.String()
+	}
+}
+
+// metricType returns the type of the family the sample belongs to, taking into account the suffixes appended to the
+// family name by counters, histograms and summaries
+func metricType(name string, types map[string]api.MetricType) api.MetricType {
+	if t, ok := types[name]; ok {
+		return t
+	}
+	for _, suffix := range []string{"_total", "_count", "_sum", "_bucket", "_created", "_info"} {
+		if t, ok := types[strings.TrimSuffix(name, suffix)]; ok && strings.HasSuffix(name, suffix) {
+			return t
+		}
 	}
-	return
+	return api.MetricTypeUnknown
 }
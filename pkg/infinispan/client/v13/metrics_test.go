@@ -0,0 +1,70 @@
+package v13
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePrometheusText(t *testing.T) {
+	text := `# HELP vendor_cache_manager_default_cache_default_cluster_cache_stats_required_minimum_number_of_nodes Required minimum nodes
+# TYPE vendor_cache_manager_default_cache_default_cluster_cache_stats_required_minimum_number_of_nodes gauge
+vendor_cache_manager_default_cache_default_cluster_cache_stats_required_minimum_number_of_nodes{node="example-infinispan-0"} 2.0
+# TYPE base_gc_total counter
+base_gc_total{name="G1 Young Generation"} 13 1395066363000
+base_memory_maxHeap_bytes -Inf
+`
+	samples, err := ParseMetrics(strings.NewReader(text))
+	assert.NoError(t, err)
+	assert.Len(t, samples, 3)
+
+	assert.Equal(t, "vendor_cache_manager_default_cache_default_cluster_cache_stats_required_minimum_number_of_nodes", samples[0].Name)
+	assert.Equal(t, api.MetricTypeGauge, samples[0].Type)
+	assert.Equal(t, map[string]string{"node": "example-infinispan-0"}, samples[0].Labels)
+	assert.Equal(t, 2.0, samples[0].Value)
+
+	assert.Equal(t, api.MetricTypeCounter, samples[1].Type)
+	assert.Equal(t, "G1 Young Generation", samples[1].Labels["name"])
+	assert.Equal(t, 13.0, samples[1].Value)
+
+	assert.Equal(t, api.MetricTypeUnknown, samples[2].Type)
+	assert.True(t, math.IsInf(samples[2].Value, -1))
+}
+
+func TestParseOpenMetrics(t *testing.T) {
+	text := `# TYPE vendor_cache_container_stats_data_memory_used gauge
+# UNIT vendor_cache_container_stats_data_memory_used bytes
+vendor_cache_container_stats_data_memory_used{cache_manager="default",node="a \"quoted\", value\\",} 1024
+# TYPE vendor_cache_requests counter
+vendor_cache_requests_total{cache="default"} 5 # {trace_id="abc"} 1.0 1520879607.789
+vendor_cache_requests_created{cache="default"} 1520872607.123
+# EOF
+`
+	samples, err := ParseMetrics(strings.NewReader(text))
+	assert.NoError(t, err)
+	assert.Len(t, samples, 3)
+
+	assert.Equal(t, `a "quoted", value\`, samples[0].Labels["node"])
+	assert.Equal(t, 1024.0, samples[0].Value)
+	assert.True(t, samples[0].Matches(map[string]string{"cache_manager": "default"}))
+	assert.False(t, samples[0].Matches(map[string]string{"cache_manager": "other"}))
+
+	assert.Equal(t, api.MetricTypeCounter, samples[1].Type)
+	assert.Equal(t, 5.0, samples[1].Value)
+	assert.Equal(t, api.MetricTypeCounter, samples[2].Type)
+}
+
+func TestParseMalformedMetrics(t *testing.T) {
+	for _, text := range []string{
+		`metric{label="value" 1`,
+		`metric{label=value} 1`,
+		`metric not-a-number`,
+		`metric`,
+	} {
+		_, err := ParseMetrics(strings.NewReader(text))
+		assert.Error(t, err, text)
+	}
+}
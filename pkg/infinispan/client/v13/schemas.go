@@ -0,0 +1,101 @@
+package v13
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	httpClient "github.com/infinispan/infinispan-operator/pkg/http"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	"github.com/infinispan/infinispan-operator/pkg/mime"
+)
+
+const SchemasPath = BasePath + "/schemas"
+
+type schemas struct {
+	httpClient.HttpClient
+}
+
+func schemaUrl(name string) string {
+	return fmt.Sprintf("%s/%s", SchemasPath, name)
+}
+
+func (s *schemas) Delete(name string) (err error) {
+	rsp, err := s.HttpClient.Delete(schemaUrl(name), nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	err = httpClient.ValidateResponse(rsp, err, "deleting schema", http.StatusOK, http.StatusNoContent, http.StatusNotFound)
+	return
+}
+
+// Errors returns the validation error of the schema, or nil if the schema is valid
+func (s *schemas) Errors(name string) (*api.SchemaError, error) {
+	infos, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range infos {
+		if info.Name == name {
+			return info.Error, nil
+		}
+	}
+	return nil, fmt.Errorf("schema '%s' not found", name)
+}
+
+func (s *schemas) Get(name string) (schema string, exists bool, err error) {
+	rsp, err := s.HttpClient.Get(schemaUrl(name), nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	if err = httpClient.ValidateResponse(rsp, err, "getting schema", http.StatusOK, http.StatusNotFound); err != nil {
+		return
+	}
+	if rsp.StatusCode == http.StatusNotFound {
+		return
+	}
+	schema, err = readResponseBody(rsp)
+	if err != nil {
+		return "", false, err
+	}
+	return schema, true, nil
+}
+
+func (s *schemas) List() (infos []api.SchemaInfo, err error) {
+	rsp, err := s.HttpClient.Get(SchemasPath, nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	if err = httpClient.ValidateResponse(rsp, err, "listing schemas", http.StatusOK); err != nil {
+		return
+	}
+
+	if err = json.NewDecoder(rsp.Body).Decode(&infos); err != nil {
+		return nil, fmt.Errorf("unable to decode: %w", err)
+	}
+	return
+}
+
+func (s *schemas) Register(name, schema string) (err error) {
+	headers := map[string]string{
+		"Content-Type": string(mime.TextPlain),
+	}
+	rsp, err := s.Post(schemaUrl(name), schema, headers)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	err = httpClient.ValidateResponse(rsp, err, "registering schema", http.StatusOK, http.StatusNoContent)
+	return
+}
+
+func (s *schemas) Update(name, schema string) (err error) {
+	headers := map[string]string{
+		"Content-Type": string(mime.TextPlain),
+	}
+	rsp, err := s.Put(schemaUrl(name), schema, headers)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	err = httpClient.ValidateResponse(rsp, err, "updating schema", http.StatusOK, http.StatusNoContent)
+	return
+}
@@ -1,9 +1,13 @@
 package v13
 
 import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 
 	httpClient "github.com/infinispan/infinispan-operator/pkg/http"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
 )
 
 const ServerPath = BasePath + "/server"
@@ -12,6 +16,38 @@ type server struct {
 	httpClient.HttpClient
 }
 
+func (s *server) Info() (info *api.ServerInfo, err error) {
+	rsp, err := s.Get(ServerPath, nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+
+	if err = httpClient.ValidateResponse(rsp, err, "getting server info", http.StatusOK); err != nil {
+		return
+	}
+
+	if err = json.NewDecoder(rsp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("unable to decode: %w", err)
+	}
+	return
+}
+
+func (s *server) Report() (report []byte, err error) {
+	rsp, err := s.Get(ServerPath+"/report", nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+
+	if err = httpClient.ValidateResponse(rsp, err, "getting server report", http.StatusOK); err != nil {
+		return
+	}
+
+	if report, err = ioutil.ReadAll(rsp.Body); err != nil {
+		return nil, fmt.Errorf("unable to read server report: %w", err)
+	}
+	return
+}
+
 func (s *server) Stop() (err error) {
 	rsp, err := s.Post(ServerPath+"?action=stop", "", nil)
 	defer func() {
@@ -20,3 +56,15 @@ func (s *server) Stop() (err error) {
 	err = httpClient.ValidateResponse(rsp, err, "stopping server", http.StatusNoContent)
 	return
 }
+
+func (s *server) ThreadDump() (dump string, err error) {
+	rsp, err := s.Get(ServerPath+"/threads", nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+
+	if err = httpClient.ValidateResponse(rsp, err, "getting thread dump", http.StatusOK); err != nil {
+		return
+	}
+	return readResponseBody(rsp)
+}
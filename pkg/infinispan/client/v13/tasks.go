@@ -0,0 +1,70 @@
+package v13
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	httpClient "github.com/infinispan/infinispan-operator/pkg/http"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	"github.com/infinispan/infinispan-operator/pkg/mime"
+)
+
+const TasksPath = BasePath + "/tasks"
+
+type tasks struct {
+	httpClient.HttpClient
+}
+
+func taskUrl(name string) string {
+	return fmt.Sprintf("%s/%s", TasksPath, name)
+}
+
+func (t *tasks) Create(name, script string) (err error) {
+	headers := map[string]string{
+		"Content-Type": string(mime.TextPlain),
+	}
+	rsp, err := t.Post(taskUrl(name), script, headers)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	err = httpClient.ValidateResponse(rsp, err, "uploading task", http.StatusOK, http.StatusNoContent)
+	return
+}
+
+// Exec executes the task with the provided parameters and returns the result
+func (t *tasks) Exec(name string, params map[string]string) (result string, err error) {
+	query := url.Values{}
+	query.Set("action", "exec")
+	for key, value := range params {
+		query.Set("param."+key, value)
+	}
+	rsp, err := t.Post(fmt.Sprintf("%s?%s", taskUrl(name), query.Encode()), "", nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	if err = httpClient.ValidateResponse(rsp, err, "executing task", http.StatusOK, http.StatusNoContent); err != nil {
+		return
+	}
+	return readResponseBody(rsp)
+}
+
+func (t *tasks) List(taskType api.TaskType) (list []api.Task, err error) {
+	path := TasksPath
+	if taskType != api.TaskTypeAll {
+		path = fmt.Sprintf("%s?type=%s", path, taskType)
+	}
+	rsp, err := t.Get(path, nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	if err = httpClient.ValidateResponse(rsp, err, "listing tasks", http.StatusOK); err != nil {
+		return
+	}
+
+	if err = json.NewDecoder(rsp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("unable to decode: %w", err)
+	}
+	return
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	httpClient "github.com/infinispan/infinispan-operator/pkg/http"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
 )
 
 const XSitePath = CacheManagerPath + "/x-site/backups"
@@ -14,36 +15,173 @@ type xsite struct {
 	httpClient.HttpClient
 }
 
+type cacheXsite struct {
+	*cache
+	httpClient.HttpClient
+}
+
+func (x *xsite) BringOnline(site string) error {
+	return siteAction(x.HttpClient, XSitePath, site, "bring-online", "bringing site online")
+}
+
+func (x *xsite) CancelPushState(site string) error {
+	return siteAction(x.HttpClient, XSitePath, site, "cancel-push-state", "cancelling xsite state push")
+}
+
 func (x *xsite) PushAllState() (err error) {
+	statuses, err := x.Status()
+	if err != nil {
+		return
+	}
+
+	// Statuses will be empty if no xsite caches are configured
+	for site, status := range statuses {
+		if status.Status == api.SiteStateOnline {
+			if err = x.PushState(site); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+func (x *xsite) PushState(site string) error {
+	return siteAction(x.HttpClient, XSitePath, site, "start-push-state", "Pushing xsite state")
+}
+
+func (x *xsite) Status() (statuses map[string]api.SiteStatus, err error) {
 	rsp, err := x.Get(XSitePath, nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
 	if err = httpClient.ValidateResponse(rsp, err, "Retrieving xsite status", http.StatusOK); err != nil {
 		return
 	}
 
+	if err = json.NewDecoder(rsp.Body).Decode(&statuses); err != nil {
+		return nil, fmt.Errorf("unable to decode: %w", err)
+	}
+	return
+}
+
+func (x *xsite) TakeOffline(site string) error {
+	return siteAction(x.HttpClient, XSitePath, site, "take-offline", "taking site offline")
+}
+
+func (x *cacheXsite) url() string {
+	return x.cache.url() + "/x-site"
+}
+
+func (x *cacheXsite) backupsUrl() string {
+	return x.url() + "/backups"
+}
+
+func (x *cacheXsite) BringOnline(site string) error {
+	return siteAction(x.HttpClient, x.backupsUrl(), site, "bring-online", "bringing cache site online")
+}
+
+func (x *cacheXsite) CancelPushState(site string) error {
+	return siteAction(x.HttpClient, x.backupsUrl(), site, "cancel-push-state", "cancelling cache xsite state push")
+}
+
+func (x *cacheXsite) ClearPushStateStatus() (err error) {
+	rsp, err := x.Post(x.url()+"/local?action=clear-push-state-status", "", nil)
 	defer func() {
-		cerr := rsp.Body.Close()
-		if err == nil {
-			err = cerr
-		}
+		err = httpClient.CloseBody(rsp, err)
 	}()
+	return httpClient.ValidateResponse(rsp, err, "clearing cache xsite push state status", http.StatusOK, http.StatusNoContent)
+}
 
-	type xsiteStatus struct {
-		Status string `json:"status"`
+func (x *cacheXsite) PushState(site string) error {
+	return siteAction(x.HttpClient, x.backupsUrl(), site, "start-push-state", "pushing cache xsite state")
+}
+
+func (x *cacheXsite) PushStateStatus() (statuses map[string]api.PushStateStatus, err error) {
+	rsp, err := x.HttpClient.Get(x.backupsUrl()+"?action=push-state-status", nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	if err = httpClient.ValidateResponse(rsp, err, "retrieving cache xsite push state status", http.StatusOK); err != nil {
+		return
 	}
-	var statuses map[string]xsiteStatus
-	if err := json.NewDecoder(rsp.Body).Decode(&statuses); err != nil {
-		return fmt.Errorf("unable to decode: %w", err)
+
+	if err = json.NewDecoder(rsp.Body).Decode(&statuses); err != nil {
+		return nil, fmt.Errorf("unable to decode: %w", err)
 	}
+	return
+}
 
-	// Statuses will be empty if no xsite caches are configured
-	for k, v := range statuses {
-		if v.Status == "online" {
-			url := fmt.Sprintf("%s/%s?action=start-push-state", XSitePath, k)
-			rsp, err = x.Post(url, "", nil)
-			if err = httpClient.ValidateResponse(rsp, err, "Pushing xsite state", http.StatusOK); err != nil {
-				return
+func (x *cacheXsite) Status() (statuses map[string]api.SiteState, err error) {
+	rsp, err := x.HttpClient.Get(x.backupsUrl()+"/", nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	if err = httpClient.ValidateResponse(rsp, err, "retrieving cache xsite status", http.StatusOK); err != nil {
+		return
+	}
+
+	// Older servers return the state of each site as a string, whereas newer servers return an object with a status field
+	var raw map[string]json.RawMessage
+	if err = json.NewDecoder(rsp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("unable to decode: %w", err)
+	}
+	statuses = make(map[string]api.SiteState, len(raw))
+	for site, value := range raw {
+		var status api.SiteStatus
+		if err := json.Unmarshal(value, &status.Status); err != nil {
+			if err := json.Unmarshal(value, &status); err != nil {
+				return nil, fmt.Errorf("unable to decode status of site '%s': %w", site, err)
 			}
 		}
+		statuses[site] = status.Status
+	}
+	return
+}
+
+func (x *cacheXsite) TakeOffline(site string) error {
+	return siteAction(x.HttpClient, x.backupsUrl(), site, "take-offline", "taking cache site offline")
+}
+
+func (x *cacheXsite) TakeOfflineConfig(site string) (config *api.TakeOfflineConfig, err error) {
+	rsp, err := x.HttpClient.Get(x.takeOfflineConfigUrl(site), nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	if err = httpClient.ValidateResponse(rsp, err, "retrieving cache xsite take offline config", http.StatusOK); err != nil {
+		return
+	}
+
+	config = &api.TakeOfflineConfig{}
+	if err = json.NewDecoder(rsp.Body).Decode(config); err != nil {
+		return nil, fmt.Errorf("unable to decode: %w", err)
 	}
 	return
 }
+
+func (x *cacheXsite) UpdateTakeOfflineConfig(site string, config *api.TakeOfflineConfig) (err error) {
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("unable to encode take offline config: %w", err)
+	}
+
+	headers := map[string]string{"Content-Type": "application/json"}
+	rsp, err := x.HttpClient.Put(x.takeOfflineConfigUrl(site), string(payload), headers)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	return httpClient.ValidateResponse(rsp, err, "updating cache xsite take offline config", http.StatusOK, http.StatusNoContent)
+}
+
+func (x *cacheXsite) takeOfflineConfigUrl(site string) string {
+	return fmt.Sprintf("%s/%s/take-offline-config", x.backupsUrl(), site)
+}
+
+// siteAction executes the provided action on a backup site via a POST request
+func siteAction(client httpClient.HttpClient, path, site, action, entity string) (err error) {
+	url := fmt.Sprintf("%s/%s?action=%s", path, site, action)
+	rsp, err := client.Post(url, "", nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	return httpClient.ValidateResponse(rsp, err, entity, http.StatusOK, http.StatusNoContent)
+}
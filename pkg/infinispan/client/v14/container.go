@@ -0,0 +1,108 @@
+package v14
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	httpClient "github.com/infinispan/infinispan-operator/pkg/http"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	v13 "github.com/infinispan/infinispan-operator/pkg/infinispan/client/v13"
+)
+
+// Infinispan 14 exposes the cache-container resources under the container path, deprecating the cache-managers endpoints
+const (
+	HealthPath       = v13.ContainerPath + "/health"
+	HealthStatusPath = HealthPath + "/status"
+)
+
+type container struct {
+	httpClient.HttpClient
+	container13 api.Container
+}
+
+func (c *container) Info() (info *api.ContainerInfo, err error) {
+	rsp, err := c.Get(v13.ContainerPath, nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+
+	if err = httpClient.ValidateResponse(rsp, err, "getting container info", http.StatusOK); err != nil {
+		return
+	}
+
+	if err = json.NewDecoder(rsp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("unable to decode: %w", err)
+	}
+	return
+}
+
+func (c *container) Backups() api.Backups {
+	return c.container13.Backups()
+}
+
+func (c *container) DisableRebalancing() error {
+	return c.container13.DisableRebalancing()
+}
+
+func (c *container) EnableRebalancing() error {
+	return c.container13.EnableRebalancing()
+}
+
+func (c *container) HealthStatus() (status api.HealthStatus, err error) {
+	rsp, err := c.Get(HealthStatusPath, nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+
+	if err = httpClient.ValidateResponse(rsp, err, "getting container health status", http.StatusOK); err != nil {
+		return
+	}
+	all, err := ioutil.ReadAll(rsp.Body)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode: %w", err)
+	}
+	return api.HealthStatus(string(all)), nil
+}
+
+func (c *container) Members() (members []string, err error) {
+	rsp, err := c.Get(HealthPath, nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+
+	if err = httpClient.ValidateResponse(rsp, err, "getting cluster members", http.StatusOK); err != nil {
+		return
+	}
+
+	type Health struct {
+		ClusterHealth struct {
+			Nodes []string `json:"node_names"`
+		} `json:"cluster_health"`
+	}
+
+	var health Health
+	if err := json.NewDecoder(rsp.Body).Decode(&health); err != nil {
+		return nil, fmt.Errorf("unable to decode: %w", err)
+	}
+	return health.ClusterHealth.Nodes, nil
+}
+
+func (c *container) Restores() api.Restores {
+	return c.container13.Restores()
+}
+
+func (c *container) Shutdown() error {
+	return c.container13.Shutdown()
+}
+
+// ShutdownTask is not required on Infinispan 14, as ISPN-13141 has been resolved and the server images no longer provide
+// a javascript engine for executing scripts. Instead, the container is shutdown directly.
+func (c *container) ShutdownTask() error {
+	return c.container13.Shutdown()
+}
+
+func (c *container) Xsite() api.Xsite {
+	return c.container13.Xsite()
+}
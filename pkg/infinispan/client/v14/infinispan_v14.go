@@ -0,0 +1,56 @@
+// Package v14 implements a client for interacting with Infinispan 14.x servers
+package v14
+
+import (
+	"github.com/infinispan/infinispan-operator/pkg/http"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	v13 "github.com/infinispan/infinispan-operator/pkg/infinispan/client/v13"
+)
+
+type infinispan struct {
+	http.HttpClient
+	ispn13 api.Infinispan
+}
+
+func New(client http.HttpClient) api.Infinispan {
+	return &infinispan{
+		HttpClient: client,
+		ispn13:     v13.New(client),
+	}
+}
+
+func (i *infinispan) Cache(name string) api.Cache {
+	return i.ispn13.Cache(name)
+}
+
+func (i *infinispan) Caches() api.Caches {
+	return i.ispn13.Caches()
+}
+
+func (i *infinispan) Container() api.Container {
+	return &container{i.HttpClient, i.ispn13.Container()}
+}
+
+func (i *infinispan) Counters() api.Counters {
+	return i.ispn13.Counters()
+}
+
+func (i *infinispan) Logging() api.Logging {
+	return i.ispn13.Logging()
+}
+
+func (i *infinispan) Metrics() api.Metrics {
+	return i.ispn13.Metrics()
+}
+
+func (i *infinispan) Schemas() api.Schemas {
+	return i.ispn13.Schemas()
+}
+
+func (i *infinispan) Server() api.Server {
+	return i.ispn13.Server()
+}
+
+func (i *infinispan) Tasks() api.Tasks {
+	return i.ispn13.Tasks()
+}
@@ -15,7 +15,9 @@ func Generate(v *version.Version, spec *Spec) (string, error) {
 	}
 	switch v.Major {
 	case 13:
-		return templates.LoadAndExecute("log4j.xml", nil, spec)
+		return templates.LoadAndExecute("log4j-13.xml", nil, spec)
+	case 14:
+		return templates.LoadAndExecute("log4j-14.xml", nil, spec)
 	default:
 		return "", version.UnknownError(v)
 	}
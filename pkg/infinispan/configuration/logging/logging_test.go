@@ -0,0 +1,35 @@
+package logging
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/version"
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestGenerate(t *testing.T) {
+	spec := &Spec{
+		Categories: map[string]string{
+			"org.infinispan":        "debug",
+			"org.infinispan.server": "trace",
+		},
+	}
+	for _, major := range []uint64{13, 14} {
+		golden := filepath.Join("testdata", fmt.Sprintf("log4j-%d.xml", major))
+		config, err := Generate(&version.Version{Major: major}, spec)
+		assert.NoError(t, err)
+
+		if *update {
+			assert.NoError(t, ioutil.WriteFile(golden, []byte(config), 0644))
+		}
+		expected, err := ioutil.ReadFile(golden)
+		assert.NoError(t, err)
+		assert.Equal(t, string(expected), config, golden)
+	}
+}
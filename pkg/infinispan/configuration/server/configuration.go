@@ -111,6 +111,8 @@ func Generate(v *version.Version, spec *Spec) (string, error) {
 	switch v.Major {
 	case 13:
 		return templates.LoadAndExecute("infinispan-13.xml", funcMap, spec)
+	case 14:
+		return templates.LoadAndExecute("infinispan-14.xml", funcMap, spec)
 	default:
 		return "", version.UnknownError(v)
 	}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/version"
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+var specs = map[string]*Spec{
+	"default": {
+		ClusterName:     "example-infinispan",
+		Namespace:       "testing-namespace",
+		StatefulSetName: "example-infinispan",
+		Infinispan: Infinispan{
+			Authorization: &Authorization{
+				RoleMapper: "cluster",
+			},
+		},
+		Endpoints: Endpoints{
+			Authenticate: true,
+			ClientCert:   "None",
+		},
+	},
+	"full": {
+		ClusterName:     "example-infinispan",
+		Namespace:       "testing-namespace",
+		StatefulSetName: "example-infinispan",
+		Infinispan: Infinispan{
+			Authorization: &Authorization{
+				Enabled:    true,
+				RoleMapper: "commonName",
+				Roles: []AuthorizationRole{
+					{Name: "reader", Permissions: "READ"},
+				},
+			},
+		},
+		JGroups: JGroups{
+			Diagnostics: true,
+			FastMerge:   true,
+		},
+		CloudEvents: &CloudEvents{
+			Acks:              "1",
+			BootstrapServers:  "kafka:9092",
+			CacheEntriesTopic: "entries",
+		},
+		Endpoints: Endpoints{
+			Authenticate: true,
+			ClientCert:   "Authenticate",
+		},
+		Keystore: Keystore{
+			Path:     "/etc/encrypt/keystore.p12",
+			Password: "password",
+			Alias:    "server",
+		},
+		Truststore: Truststore{
+			Path:     "/etc/encrypt/truststore.p12",
+			Password: "password",
+		},
+		Transport: Transport{
+			TLS: TransportTLS{
+				Enabled: true,
+				KeyStore: Keystore{
+					Path:     "/etc/transport/keystore.p12",
+					Password: "password",
+					Alias:    "transport",
+				},
+				TrustStore: Truststore{
+					Path:     "/etc/transport/truststore.p12",
+					Password: "password",
+				},
+			},
+		},
+		XSite: &XSite{
+			MaxRelayNodes: 1,
+			Sites: []BackupSite{
+				{Address: "site-a.svc", Name: "SiteA", Port: 7900},
+				{Address: "site-b.svc", Name: "SiteB", Port: 7900},
+			},
+		},
+	},
+}
+
+func TestGenerate(t *testing.T) {
+	for _, major := range []uint64{13, 14} {
+		for name, spec := range specs {
+			golden := filepath.Join("testdata", fmt.Sprintf("infinispan-%d-%s.xml", major, name))
+			config, err := Generate(&version.Version{Major: major}, spec)
+			assert.NoError(t, err)
+
+			if *update {
+				assert.NoError(t, ioutil.WriteFile(golden, []byte(config), 0644))
+			}
+			expected, err := ioutil.ReadFile(golden)
+			assert.NoError(t, err)
+			assert.Equal(t, string(expected), config, golden)
+		}
+	}
+}
+
+func TestGenerateUnknownVersion(t *testing.T) {
+	_, err := Generate(&version.Version{Major: 12}, specs["default"])
+	assert.Error(t, err)
+}
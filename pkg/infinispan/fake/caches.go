@@ -0,0 +1,352 @@
+package fake
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+)
+
+func (s *Server) caches(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 || path[0] == "" {
+		switch {
+		case r.Method == http.MethodGet:
+			writeJSON(w, s.cacheNames())
+		case r.Method == http.MethodPost && action(r) == "convert":
+			// Configurations are stored as provided, so conversion returns the configuration unchanged
+			body, _ := ioutil.ReadAll(r.Body)
+			w.Header().Set("Content-Type", r.Header.Get("Accept"))
+			_, _ = w.Write(body)
+		default:
+			notImplemented(w, r)
+		}
+		return
+	}
+
+	name := path[0]
+	if len(path) == 1 {
+		s.cache(w, r, name)
+		return
+	}
+
+	c, exists := s.state.Caches[name]
+	if !exists {
+		notFound(w, "cache '%s' not found", name)
+		return
+	}
+
+	switch path[1] {
+	case "rolling-upgrade":
+		if len(path) != 3 || path[2] != "source-connection" {
+			notImplemented(w, r)
+			return
+		}
+		s.sourceConnection(w, r, c)
+	case "search":
+		s.search(w, r, path[2:])
+	case "x-site":
+		s.cacheXsite(w, r, c, path[2:])
+	default:
+		if len(path) != 2 {
+			notImplemented(w, r)
+			return
+		}
+		s.entry(w, r, c, path[1])
+	}
+}
+
+func (s *Server) cache(w http.ResponseWriter, r *http.Request, name string) {
+	c, exists := s.state.Caches[name]
+	if r.Method == http.MethodPost && action(r) == "" {
+		if exists {
+			http.Error(w, "cache '"+name+"' already exists", http.StatusConflict)
+			return
+		}
+		config := ""
+		if template := r.URL.Query().Get("template"); template != "" {
+			templateConfig, ok := s.state.Templates[template]
+			if !ok {
+				badRequest(w, "template '%s' not found", template)
+				return
+			}
+			config = templateConfig
+		} else {
+			body, _ := ioutil.ReadAll(r.Body)
+			config = string(body)
+		}
+		s.addCache(name, config)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if !exists {
+		notFound(w, "cache '%s' not found", name)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodHead:
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		delete(s.state.Caches, name)
+		w.WriteHeader(http.StatusOK)
+	case http.MethodPut:
+		body, _ := ioutil.ReadAll(r.Body)
+		c.Config = string(body)
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		s.cacheGet(w, r, c)
+	case http.MethodPost:
+		s.cachePost(w, r, c)
+	default:
+		notImplemented(w, r)
+	}
+}
+
+func (s *Server) cacheGet(w http.ResponseWriter, r *http.Request, c *Cache) {
+	switch action(r) {
+	case "":
+		writeJSON(w, map[string]interface{}{
+			"stats": api.CacheStats{
+				Entries:         int64(len(c.Entries)),
+				EntriesInMemory: int64(len(c.Entries)),
+			},
+			"rebalancing_enabled": c.RebalancingEnabled,
+			"rehash_in_progress":  false,
+		})
+	case "config":
+		if json.Valid([]byte(c.Config)) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(c.Config))
+		} else {
+			writeJSON(w, c.Config)
+		}
+	case "entries":
+		s.cacheEntries(w, r, c)
+	case "get-availability":
+		writeText(w, string(c.Availability))
+	case "keys":
+		keys := make([]string, 0, len(c.Entries))
+		for key := range c.Entries {
+			keys = append(keys, key)
+		}
+		writeJSON(w, keys)
+	case "search":
+		writeJSON(w, map[string]interface{}{
+			"hit_count":       0,
+			"hit_count_exact": true,
+			"hits":            []interface{}{},
+		})
+	case "size":
+		writeText(w, strconv.Itoa(len(c.Entries)))
+	default:
+		notImplemented(w, r)
+	}
+}
+
+func (s *Server) cacheEntries(w http.ResponseWriter, r *http.Request, c *Cache) {
+	metadata, _ := strconv.ParseBool(r.URL.Query().Get("metadata"))
+	entries := []map[string]interface{}{}
+	for key, e := range c.Entries {
+		entry := map[string]interface{}{
+			"key":   key,
+			"value": e.Value,
+		}
+		if metadata {
+			entry["timeToLiveSeconds"] = e.Lifespan
+			entry["maxIdleTimeSeconds"] = e.MaxIdle
+			entry["created"] = -1
+			entry["lastUsed"] = -1
+			entry["expireTime"] = -1
+			entry["version"] = e.Version
+		}
+		entries = append(entries, entry)
+	}
+	writeJSON(w, entries)
+}
+
+func (s *Server) cachePost(w http.ResponseWriter, r *http.Request, c *Cache) {
+	switch action(r) {
+	case "clear":
+		c.Entries = map[string]*Entry{}
+	case "disable-rebalancing":
+		c.RebalancingEnabled = false
+	case "enable-rebalancing":
+		c.RebalancingEnabled = true
+	case "set-availability":
+		availability := api.CacheAvailability(r.URL.Query().Get("availability"))
+		if availability != api.CacheAvailabilityAvailable && availability != api.CacheAvailabilityDegraded {
+			badRequest(w, "unknown availability '%s'", availability)
+			return
+		}
+		c.Availability = availability
+	case "sync-data":
+		if c.SourceConnection == "" {
+			badRequest(w, "no source connection")
+			return
+		}
+		writeText(w, "0 entries migrated")
+		return
+	default:
+		notImplemented(w, r)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// entry handles the operations on a single entry. Conditional writes are supported via If-Match, with the ETag of an
+// entry being its version
+func (s *Server) entry(w http.ResponseWriter, r *http.Request, c *Cache, key string) {
+	e, exists := c.Entries[key]
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", e.ContentType)
+		w.Header().Set("ETag", etag(e))
+		w.Header().Set("timeToLiveSeconds", strconv.FormatInt(e.Lifespan, 10))
+		w.Header().Set("maxIdleTimeSeconds", strconv.FormatInt(e.MaxIdle, 10))
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(e.Value))
+		}
+	case http.MethodPost, http.MethodPut:
+		if r.Method == http.MethodPost && exists {
+			http.Error(w, "entry '"+key+"' already exists", http.StatusConflict)
+			return
+		}
+		if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && (!exists || ifMatch != etag(e)) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		s.version++
+		c.Entries[key] = &Entry{
+			Value:       string(body),
+			ContentType: r.Header.Get("Content-Type"),
+			Version:     s.version,
+			Lifespan:    headerInt(r.Header, "timeToLiveSeconds"),
+			MaxIdle:     headerInt(r.Header, "maxIdleTimeSeconds"),
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		if !exists {
+			notFound(w, "entry '%s' not found", key)
+			return
+		}
+		delete(c.Entries, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		notImplemented(w, r)
+	}
+}
+
+func (s *Server) sourceConnection(w http.ResponseWriter, r *http.Request, c *Cache) {
+	switch r.Method {
+	case http.MethodHead:
+		if c.SourceConnection == "" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	case http.MethodPost:
+		body, _ := ioutil.ReadAll(r.Body)
+		c.SourceConnection = string(body)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		c.SourceConnection = ""
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		notImplemented(w, r)
+	}
+}
+
+func (s *Server) search(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 1 && path[0] == "indexes" && r.Method == http.MethodPost:
+		if a := action(r); a != "clear" && a != "reindex" {
+			badRequest(w, "unknown action '%s'", a)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case len(path) == 1 && path[0] == "stats" && r.Method == http.MethodGet:
+		writeJSON(w, &api.SearchStats{})
+	default:
+		notImplemented(w, r)
+	}
+}
+
+func (s *Server) cacheXsite(w http.ResponseWriter, r *http.Request, c *Cache, path []string) {
+	switch {
+	case len(path) == 2 && path[0] == "local" && r.Method == http.MethodPost && action(r) == "clear-push-state-status":
+		for _, site := range c.Sites {
+			site.PushStateStatus = ""
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case len(path) == 1 && path[0] == "backups" && r.Method == http.MethodGet:
+		if action(r) == "push-state-status" {
+			statuses := map[string]api.PushStateStatus{}
+			for name, site := range c.Sites {
+				if site.PushStateStatus != "" {
+					statuses[name] = site.PushStateStatus
+				}
+			}
+			writeJSON(w, statuses)
+			return
+		}
+		statuses := map[string]api.SiteStatus{}
+		for name, site := range c.Sites {
+			statuses[name] = api.SiteStatus{Status: site.Status}
+		}
+		writeJSON(w, statuses)
+	case len(path) >= 2 && path[0] == "backups":
+		site, ok := c.Sites[path[1]]
+		if !ok {
+			notFound(w, "site '%s' not found", path[1])
+			return
+		}
+		s.cacheSite(w, r, site, path[2:])
+	default:
+		notImplemented(w, r)
+	}
+}
+
+func (s *Server) cacheSite(w http.ResponseWriter, r *http.Request, site *CacheSite, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodPost:
+		if !siteAction(site, action(r)) {
+			badRequest(w, "unknown action '%s'", action(r))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	case len(path) == 1 && path[0] == "take-offline-config" && r.Method == http.MethodGet:
+		writeJSON(w, &site.TakeOfflineConfig)
+	case len(path) == 1 && path[0] == "take-offline-config" && r.Method == http.MethodPut:
+		config := api.TakeOfflineConfig{}
+		if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+			badRequest(w, "invalid take offline config: %v", err)
+			return
+		}
+		site.TakeOfflineConfig = config
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		notImplemented(w, r)
+	}
+}
+
+func etag(e *Entry) string {
+	return strconv.Quote(strconv.FormatInt(e.Version, 10))
+}
+
+func headerInt(header http.Header, key string) int64 {
+	value := strings.TrimSpace(header.Get(key))
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return -1
+	}
+	return i
+}
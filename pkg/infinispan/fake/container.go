@@ -0,0 +1,273 @@
+package fake
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sort"
+
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+)
+
+// container handles the cache-managers/default and container endpoints, which are equivalent
+func (s *Server) container(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 {
+		switch {
+		case r.Method == http.MethodGet:
+			s.containerInfo(w)
+		case r.Method == http.MethodPost && action(r) == "enable-rebalancing":
+			s.setRebalancing(w, true)
+		case r.Method == http.MethodPost && action(r) == "disable-rebalancing":
+			s.setRebalancing(w, false)
+		case r.Method == http.MethodPost && action(r) == "shutdown":
+			s.state.Shutdown = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			notImplemented(w, r)
+		}
+		return
+	}
+
+	switch path[0] {
+	case "health":
+		s.health(w, r, path[1:])
+	case "backups":
+		s.operation(w, r, path[1:], s.state.Backups)
+	case "restores":
+		s.operation(w, r, path[1:], s.state.Restores)
+	case "cache-configs":
+		if len(path) != 2 || path[1] != "templates" || r.Method != http.MethodGet {
+			notImplemented(w, r)
+			return
+		}
+		s.templates(w)
+	case "x-site":
+		if len(path) < 2 || path[1] != "backups" {
+			notImplemented(w, r)
+			return
+		}
+		s.xsite(w, r, path[2:])
+	default:
+		notImplemented(w, r)
+	}
+}
+
+func (s *Server) containerInfo(w http.ResponseWriter) {
+	sitesView := []string{"local"}
+	sitesView = append(sitesView, s.state.Sites...)
+	writeJSON(w, map[string]interface{}{
+		"version":             s.state.Version,
+		"coordinator":         s.state.Coordinator,
+		"cluster_members":     s.state.Members,
+		"cluster_size":        len(s.state.Members),
+		"rebalancing_enabled": s.state.RebalancingEnabled,
+		"sites_view":          sitesView,
+	})
+}
+
+func (s *Server) templates(w http.ResponseWriter) {
+	names := make([]string, 0, len(s.state.Templates))
+	for name := range s.state.Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	templates := make([]map[string]interface{}, len(names))
+	for i, name := range names {
+		templates[i] = map[string]interface{}{
+			"name":          name,
+			"configuration": json.RawMessage(s.state.Templates[name]),
+		}
+	}
+	writeJSON(w, templates)
+}
+
+func (s *Server) setRebalancing(w http.ResponseWriter, enabled bool) {
+	s.state.RebalancingEnabled = enabled
+	for _, c := range s.state.Caches {
+		c.RebalancingEnabled = enabled
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) health(w http.ResponseWriter, r *http.Request, path []string) {
+	if r.Method != http.MethodGet {
+		notImplemented(w, r)
+		return
+	}
+
+	if len(path) == 1 && path[0] == "status" {
+		writeText(w, string(s.state.Health))
+		return
+	} else if len(path) > 0 {
+		notImplemented(w, r)
+		return
+	}
+
+	type cacheHealth struct {
+		Name   string           `json:"cache_name"`
+		Status api.HealthStatus `json:"status"`
+	}
+	caches := []cacheHealth{}
+	for _, name := range s.cacheNames() {
+		status := api.HealthStatusHealth
+		if s.state.Caches[name].Availability == api.CacheAvailabilityDegraded {
+			status = api.HealthStatusDegraded
+		}
+		caches = append(caches, cacheHealth{Name: name, Status: status})
+	}
+	writeJSON(w, map[string]interface{}{
+		"cluster_health": map[string]interface{}{
+			"health_status":      s.state.Health,
+			"number_of_nodes":    len(s.state.Members),
+			"node_names":         s.state.Members,
+			"cluster_name":       "cluster",
+			"cache_manager_name": "default",
+		},
+		"cache_health": caches,
+	})
+}
+
+// operation handles the creation and status of backups and restores
+func (s *Server) operation(w http.ResponseWriter, r *http.Request, path []string, operations map[string]*Operation) {
+	if len(path) != 1 {
+		notImplemented(w, r)
+		return
+	}
+
+	name := path[0]
+	op, exists := operations[name]
+	switch r.Method {
+	case http.MethodPost:
+		if exists {
+			http.Error(w, "operation '"+name+"' already exists", http.StatusConflict)
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil || !json.Valid(body) {
+			badRequest(w, "invalid JSON body")
+			return
+		}
+		operations[name] = &Operation{
+			Config: body,
+			Status: s.state.OperationStatus,
+		}
+		w.WriteHeader(http.StatusAccepted)
+	case http.MethodHead:
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch op.Status {
+		case api.StatusSucceeded:
+			w.WriteHeader(http.StatusCreated)
+		case api.StatusRunning:
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	case http.MethodDelete:
+		if !exists {
+			notFound(w, "operation '%s' not found", name)
+			return
+		}
+		delete(operations, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		notImplemented(w, r)
+	}
+}
+
+// xsite handles the container level cross-site operations, which are applied to all caches that backup to the site
+func (s *Server) xsite(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		statuses := map[string]api.SiteStatus{}
+		for _, site := range s.state.Sites {
+			statuses[site] = s.siteStatus(site)
+		}
+		writeJSON(w, statuses)
+	case len(path) == 1 && r.Method == http.MethodPost:
+		site := path[0]
+		if !s.siteExists(site) {
+			notFound(w, "site '%s' not found", site)
+			return
+		}
+		for _, c := range s.state.Caches {
+			if cacheSite, ok := c.Sites[site]; ok {
+				if !siteAction(cacheSite, action(r)) {
+					badRequest(w, "unknown action '%s'", action(r))
+					return
+				}
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		notImplemented(w, r)
+	}
+}
+
+func (s *Server) siteExists(site string) bool {
+	for _, name := range s.state.Sites {
+		if name == site {
+			return true
+		}
+	}
+	return false
+}
+
+// siteStatus returns the state of a site across all caches, listing the caches in each state if the site is mixed
+func (s *Server) siteStatus(site string) api.SiteStatus {
+	status := api.SiteStatus{}
+	for _, name := range s.cacheNames() {
+		cacheSite, ok := s.state.Caches[name].Sites[site]
+		if !ok {
+			continue
+		}
+		if cacheSite.Status == api.SiteStateOnline {
+			status.Online = append(status.Online, name)
+		} else {
+			status.Offline = append(status.Offline, name)
+		}
+	}
+
+	switch {
+	case len(status.Online) > 0 && len(status.Offline) > 0:
+		status.Status = api.SiteStateMixed
+	case len(status.Offline) > 0:
+		status.Status, status.Offline = api.SiteStateOffline, nil
+	default:
+		status.Status, status.Online = api.SiteStateOnline, nil
+	}
+	return status
+}
+
+// siteAction applies a cross-site action to a cache's site. Pushing state completes immediately, unless the site is
+// offline in which case the push fails. False is returned if the action is unknown
+func siteAction(site *CacheSite, action string) bool {
+	switch action {
+	case "bring-online":
+		site.Status = api.SiteStateOnline
+	case "take-offline":
+		site.Status = api.SiteStateOffline
+	case "start-push-state":
+		if site.Status == api.SiteStateOnline {
+			site.PushStateStatus = api.PushStateStatusOK
+		} else {
+			site.PushStateStatus = api.PushStateStatusError
+		}
+	case "cancel-push-state":
+		site.PushStateStatus = api.PushStateStatusError
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *Server) cacheNames() []string {
+	names := make([]string, 0, len(s.state.Caches))
+	for name := range s.state.Caches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,121 @@
+package fake
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+)
+
+func (s *Server) counters(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 || path[0] == "" {
+		if r.Method != http.MethodGet {
+			notImplemented(w, r)
+			return
+		}
+		names := make([]string, 0, len(s.state.Counters))
+		for name := range s.state.Counters {
+			names = append(names, name)
+		}
+		writeJSON(w, names)
+		return
+	}
+
+	name := path[0]
+	c, exists := s.state.Counters[name]
+	if len(path) == 1 && r.Method == http.MethodPost && action(r) == "" {
+		s.counterCreate(w, r, name, exists)
+		return
+	}
+	if !exists {
+		notFound(w, "counter '%s' not found", name)
+		return
+	}
+
+	switch {
+	case len(path) == 2 && path[1] == "config" && r.Method == http.MethodGet:
+		writeJSON(w, map[api.CounterType]*api.CounterConfig{c.Config.Type: &c.Config})
+	case len(path) > 1:
+		notImplemented(w, r)
+	case r.Method == http.MethodGet:
+		writeText(w, strconv.FormatInt(c.Value, 10))
+	case r.Method == http.MethodDelete:
+		delete(s.state.Counters, name)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPost:
+		s.counterAction(w, r, c)
+	default:
+		notImplemented(w, r)
+	}
+}
+
+func (s *Server) counterCreate(w http.ResponseWriter, r *http.Request, name string, exists bool) {
+	if exists {
+		http.Error(w, "counter '"+name+"' already exists", http.StatusConflict)
+		return
+	}
+	var configs map[api.CounterType]*api.CounterConfig
+	if err := json.NewDecoder(r.Body).Decode(&configs); err != nil {
+		badRequest(w, "unable to decode counter configuration: %v", err)
+		return
+	}
+	for counterType, config := range configs {
+		if config == nil || (counterType != api.CounterTypeStrong && counterType != api.CounterTypeWeak) {
+			continue
+		}
+		config.Type = counterType
+		if config.Storage == "" {
+			config.Storage = api.CounterStorageVolatile
+		}
+		s.state.Counters[name] = &Counter{Config: *config, Value: config.InitialValue}
+		return
+	}
+	badRequest(w, "unknown counter configuration")
+}
+
+func (s *Server) counterAction(w http.ResponseWriter, r *http.Request, c *Counter) {
+	query := r.URL.Query()
+	switch action(r) {
+	case "add":
+		delta, err := strconv.ParseInt(query.Get("delta"), 10, 64)
+		if err != nil {
+			badRequest(w, "invalid delta: %v", err)
+			return
+		}
+		s.counterUpdate(w, c, c.Value+delta)
+	case "increment":
+		s.counterUpdate(w, c, c.Value+1)
+	case "reset":
+		c.Value = c.Config.InitialValue
+		w.WriteHeader(http.StatusNoContent)
+	case "compareAndSet":
+		expect, err := strconv.ParseInt(query.Get("expect"), 10, 64)
+		if err != nil {
+			badRequest(w, "invalid expect: %v", err)
+			return
+		}
+		update, err := strconv.ParseInt(query.Get("update"), 10, 64)
+		if err != nil {
+			badRequest(w, "invalid update: %v", err)
+			return
+		}
+		set := c.Value == expect
+		if set {
+			c.Value = update
+		}
+		writeText(w, strconv.FormatBool(set))
+	default:
+		notImplemented(w, r)
+	}
+}
+
+// counterUpdate sets the value of the counter, weak counters do not return the updated value
+func (s *Server) counterUpdate(w http.ResponseWriter, c *Counter, value int64) {
+	c.Value = value
+	if c.Config.Type == api.CounterTypeWeak {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeText(w, strconv.FormatInt(value, 10))
+}
@@ -0,0 +1,61 @@
+package fake
+
+import (
+	"io/ioutil"
+	"net/http"
+	"sort"
+
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+)
+
+func (s *Server) schemas(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 || path[0] == "" {
+		if r.Method != http.MethodGet {
+			notImplemented(w, r)
+			return
+		}
+		infos := make([]api.SchemaInfo, 0, len(s.state.Schemas))
+		for name, schema := range s.state.Schemas {
+			infos = append(infos, api.SchemaInfo{Name: name, Error: schema.Error})
+		}
+		sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+		writeJSON(w, infos)
+		return
+	}
+	if len(path) > 1 {
+		notImplemented(w, r)
+		return
+	}
+
+	name := path[0]
+	schema, exists := s.state.Schemas[name]
+	switch r.Method {
+	case http.MethodGet:
+		if !exists {
+			notFound(w, "schema '%s' not found", name)
+			return
+		}
+		writeText(w, schema.Content)
+	case http.MethodPost, http.MethodPut:
+		if r.Method == http.MethodPost && exists {
+			http.Error(w, "schema '"+name+"' already exists", http.StatusConflict)
+			return
+		}
+		content, _ := ioutil.ReadAll(r.Body)
+		if !exists {
+			schema = &Schema{}
+			s.state.Schemas[name] = schema
+		}
+		schema.Content = string(content)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		if !exists {
+			notFound(w, "schema '%s' not found", name)
+			return
+		}
+		delete(s.state.Schemas, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		notImplemented(w, r)
+	}
+}
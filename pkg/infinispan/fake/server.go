@@ -0,0 +1,351 @@
+/*
+Package fake provides an in-process implementation of the Infinispan REST v2 API, backed by an in-memory State, so that
+the client and controllers can be tested without a running server.
+
+A Server should be used with a native.Client, for example via the Client or Infinispan methods:
+
+	server := fake.NewServer()
+	defer server.Close()
+
+	server.Update(func(s *fake.State) {
+		s.Health = api.HealthStatusDegraded
+	})
+	ispnClient := server.Infinispan()
+
+Only the endpoints used by the operator for caches, counters, schemas, tasks, the cache-container health and members,
+backups and restores, loggers, rolling upgrades and cross-site replication are implemented. Requests to all other endpoints receive a
+501 Not Implemented response.
+*/
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/infinispan/infinispan-operator/pkg/http/native"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+)
+
+const (
+	DefaultVersion = "13.0.10.Final"
+
+	basePath = "/rest/v2/"
+)
+
+// State the in-memory model of the cluster exposed by a Server
+type State struct {
+	// Version the server version returned by the server info endpoint
+	Version string
+	// Coordinator whether the member serving requests is the cluster coordinator
+	Coordinator bool
+	Members     []string
+	Health      api.HealthStatus
+	// RebalancingEnabled the container level rebalancing state. Toggling rebalancing on the container also toggles
+	// rebalancing on all of the caches
+	RebalancingEnabled bool
+	// Shutdown whether the container has been shutdown or the server stopped
+	Shutdown bool
+	Caches   map[string]*Cache
+	// Templates the configuration of the templates that can be used to create caches, keyed by template name
+	Templates map[string]string
+	Backups   map[string]*Operation
+	Restores  map[string]*Operation
+	// OperationStatus the status assigned to newly created backups and restores
+	OperationStatus api.Status
+	Loggers         map[string]string
+	// Sites the names of the backup sites that are part of the cross-site view, excluding the local site
+	Sites []string
+	// Counters the counters keyed by name
+	Counters map[string]*Counter
+	// Schemas the registered Protobuf schemas keyed by name
+	Schemas map[string]*Schema
+	// Tasks the uploaded script tasks keyed by name
+	Tasks map[string]*Task
+	// Metrics the body returned by the metrics endpoint in the Prometheus text exposition format
+	Metrics string
+	// Report the server report archive
+	Report []byte
+	// ThreadDump the thread dump of the server
+	ThreadDump string
+}
+
+// Cache the state of a single cache
+type Cache struct {
+	Config             string
+	Availability       api.CacheAvailability
+	RebalancingEnabled bool
+	Entries            map[string]*Entry
+	// SourceConnection the remote store configuration added by a rolling upgrade, empty when no source is connected
+	SourceConnection string
+	// Sites the cross-site state of the cache for each backup site
+	Sites map[string]*CacheSite
+}
+
+// Counter the configuration and current value of a counter
+type Counter struct {
+	Config api.CounterConfig
+	Value  int64
+}
+
+// Schema a registered Protobuf schema. Schemas are not validated, Error is returned as the validation error
+type Schema struct {
+	Content string
+	Error   *api.SchemaError
+}
+
+// Task an uploaded script task. Executions records the parameters of each execution and Result is returned by all
+// executions
+type Task struct {
+	Script     string
+	Executions []map[string]string
+	Result     string
+}
+
+// Entry a cache entry. Lifespan and MaxIdle are in seconds, with -1 meaning that the entry does not expire
+type Entry struct {
+	Value       string
+	ContentType string
+	Version     int64
+	Lifespan    int64
+	MaxIdle     int64
+}
+
+// CacheSite the state of a cache's backup site
+type CacheSite struct {
+	Status            api.SiteState
+	PushStateStatus   api.PushStateStatus
+	TakeOfflineConfig api.TakeOfflineConfig
+}
+
+// Operation a backup or restore, the Config is the JSON body provided when the operation was created
+type Operation struct {
+	Config json.RawMessage
+	Status api.Status
+}
+
+// Server an httptest.Server that implements the Infinispan REST v2 API
+type Server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	state   *State
+	version int64
+}
+
+// NewServer starts a Server representing a single member, HEALTHY cluster with no caches
+func NewServer() *Server {
+	s := &Server{
+		state: &State{
+			Version:            DefaultVersion,
+			Coordinator:        true,
+			Members:            []string{"infinispan-0"},
+			Health:             api.HealthStatusHealth,
+			RebalancingEnabled: true,
+			Caches:             map[string]*Cache{},
+			Templates:          map[string]string{},
+			Backups:            map[string]*Operation{},
+			Restores:           map[string]*Operation{},
+			OperationStatus:    api.StatusSucceeded,
+			Loggers:            map[string]string{},
+			Counters:           map[string]*Counter{},
+			Schemas:            map[string]*Schema{},
+			Tasks:              map[string]*Task{},
+		},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Update executes f whilst holding the Server lock, allowing the State to be inspected and modified
+func (s *Server) Update(f func(state *State)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f(s.state)
+}
+
+// AddCache adds a cache with the provided configuration and returns its State
+func (s *Server) AddCache(name, config string) *Cache {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addCache(name, config)
+}
+
+// Client returns a native.Client connected to the Server
+func (s *Server) Client() *native.Client {
+	host, port, err := net.SplitHostPort(s.Listener.Addr().String())
+	if err != nil {
+		panic(err)
+	}
+	portNum, err := strconv.Atoi(port)
+	if err != nil {
+		panic(err)
+	}
+	return native.New(native.Config{
+		Host:     host,
+		Port:     portNum,
+		Protocol: "http",
+	})
+}
+
+// Infinispan returns the api.Infinispan implementation matching the State's Version, connected via a native.Client
+func (s *Server) Infinispan() api.Infinispan {
+	return client.New(s.Client(), logr.Discard())
+}
+
+func (s *Server) addCache(name, config string) *Cache {
+	c := &Cache{
+		Config:             config,
+		Availability:       api.CacheAvailabilityAvailable,
+		RebalancingEnabled: s.state.RebalancingEnabled,
+		Entries:            map[string]*Entry{},
+		Sites:              map[string]*CacheSite{},
+	}
+	for _, site := range s.state.Sites {
+		c.Sites[site] = &CacheSite{Status: api.SiteStateOnline}
+	}
+	s.state.Caches[name] = c
+	return c
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Path == "/metrics" {
+		s.metrics(w, r)
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path, basePath) {
+		notImplemented(w, r)
+		return
+	}
+	path := strings.Split(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, basePath), "/"), "/")
+	switch path[0] {
+	case "cache-managers":
+		if len(path) < 2 || path[1] != "default" {
+			notFound(w, "cache container '%s' not found", strings.Join(path[1:], "/"))
+			return
+		}
+		s.container(w, r, path[2:])
+	case "caches":
+		s.caches(w, r, path[1:])
+	case "container":
+		s.container(w, r, path[1:])
+	case "counters":
+		s.counters(w, r, path[1:])
+	case "logging":
+		s.logging(w, r, path[1:])
+	case "schemas":
+		s.schemas(w, r, path[1:])
+	case "server":
+		s.server(w, r, path[1:])
+	case "tasks":
+		s.tasks(w, r, path[1:])
+	default:
+		notImplemented(w, r)
+	}
+}
+
+func (s *Server) logging(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 || path[0] != "loggers" || len(path) > 2 {
+		notImplemented(w, r)
+		return
+	}
+
+	switch {
+	case len(path) == 1 && r.Method == http.MethodGet:
+		type logger struct {
+			Name  string `json:"name"`
+			Level string `json:"level"`
+		}
+		loggers := []logger{}
+		for name, level := range s.state.Loggers {
+			loggers = append(loggers, logger{Name: name, Level: level})
+		}
+		writeJSON(w, loggers)
+	case len(path) == 2 && r.Method == http.MethodPut:
+		level := r.URL.Query().Get("level")
+		if level == "" {
+			badRequest(w, "level must be provided")
+			return
+		}
+		s.state.Loggers[path[1]] = level
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		notImplemented(w, r)
+	}
+}
+
+func (s *Server) metrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		notImplemented(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = w.Write([]byte(s.state.Metrics))
+}
+
+func (s *Server) server(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 1 && r.Method == http.MethodGet {
+		switch path[0] {
+		case "report":
+			w.Header().Set("Content-Type", "application/gzip")
+			_, _ = w.Write(s.state.Report)
+		case "threads":
+			writeText(w, s.state.ThreadDump)
+		default:
+			notImplemented(w, r)
+		}
+		return
+	} else if len(path) > 0 {
+		notImplemented(w, r)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet:
+		writeJSON(w, &api.ServerInfo{Version: s.state.Version})
+	case r.Method == http.MethodPost && action(r) == "stop":
+		s.state.Shutdown = true
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		notImplemented(w, r)
+	}
+}
+
+func action(r *http.Request) string {
+	return r.URL.Query().Get("action")
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func writeText(w http.ResponseWriter, text string) {
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = w.Write([]byte(text))
+}
+
+func badRequest(w http.ResponseWriter, format string, a ...interface{}) {
+	http.Error(w, fmt.Sprintf(format, a...), http.StatusBadRequest)
+}
+
+func notFound(w http.ResponseWriter, format string, a ...interface{}) {
+	http.Error(w, fmt.Sprintf(format, a...), http.StatusNotFound)
+}
+
+func notImplemented(w http.ResponseWriter, r *http.Request) {
+	http.Error(w, fmt.Sprintf("%s %s is not implemented", r.Method, r.URL.RequestURI()), http.StatusNotImplemented)
+}
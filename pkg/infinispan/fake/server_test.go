@@ -0,0 +1,309 @@
+package fake
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	httpClient "github.com/infinispan/infinispan-operator/pkg/http"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/version"
+	"github.com/infinispan/infinispan-operator/pkg/mime"
+	"github.com/stretchr/testify/assert"
+)
+
+func clients(t *testing.T, server *Server) map[string]api.Infinispan {
+	ispn14, err := client.NewForVersion(&version.Version{Major: 14}, server.Client())
+	assert.NoError(t, err)
+	return map[string]api.Infinispan{
+		"v13": server.Infinispan(),
+		"v14": ispn14,
+	}
+}
+
+func TestContainer(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.Update(func(s *State) {
+		s.Members = []string{"infinispan-0", "infinispan-1"}
+		s.Health = api.HealthStatusDegraded
+	})
+
+	for v, ispn := range clients(t, server) {
+		container := ispn.Container()
+		info, err := container.Info()
+		assert.NoError(t, err, v)
+		assert.True(t, info.Coordinator, v)
+		assert.True(t, info.RebalancingEnabled, v)
+
+		members, err := container.Members()
+		assert.NoError(t, err, v)
+		assert.Equal(t, []string{"infinispan-0", "infinispan-1"}, members, v)
+
+		health, err := container.HealthStatus()
+		assert.NoError(t, err, v)
+		assert.Equal(t, api.HealthStatusDegraded, health, v)
+
+		assert.NoError(t, container.DisableRebalancing(), v)
+		server.Update(func(s *State) {
+			assert.False(t, s.RebalancingEnabled, v)
+		})
+		assert.NoError(t, container.EnableRebalancing(), v)
+	}
+}
+
+func TestCaches(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.Update(func(s *State) {
+		s.Templates["org.infinispan.DIST_SYNC"] = `{"distributed-cache":{"mode":"SYNC"}}`
+	})
+
+	ispn := server.Infinispan()
+	cache := ispn.Cache("test")
+	exists, err := cache.Exists()
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	assert.NoError(t, cache.CreateWithTemplate("org.infinispan.DIST_SYNC"))
+	config, err := cache.Config(mime.ApplicationJson)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"distributed-cache":{"mode":"SYNC"}}`, config)
+	names, err := ispn.Caches().Names()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"test"}, names)
+	names, err = ispn.Caches().TemplateNames()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"org.infinispan.DIST_SYNC"}, names)
+
+	assert.NoError(t, cache.Put("k1", "v1", mime.TextPlain))
+	// Put does not overwrite existing entries
+	var httpErr *httpClient.HttpError
+	assert.True(t, errors.As(cache.Put("k1", "v2", mime.TextPlain), &httpErr))
+	assert.Equal(t, http.StatusConflict, httpErr.Status)
+	assert.True(t, errors.Is(cache.PutIfAbsent("k1", "v2", mime.TextPlain, nil), api.ErrEntryExists))
+	assert.NoError(t, cache.Replace("k1", "v3", mime.TextPlain, nil))
+	assert.True(t, errors.Is(cache.Replace("k1", "v4", mime.TextPlain, &api.EntryOptions{ETag: `"0"`}), api.ErrPreconditionFailed))
+	value, exists, err := cache.Get("k1")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, "v3", value)
+
+	keys, err := cache.Keys(0)
+	assert.NoError(t, err)
+	assert.True(t, keys.Next())
+	assert.Equal(t, "k1", keys.Key())
+	assert.False(t, keys.Next())
+	assert.NoError(t, keys.Err())
+	assert.NoError(t, keys.Close())
+
+	size, err := cache.Size()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, size)
+	assert.NoError(t, cache.Clear())
+	size, err = cache.Size()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, size)
+
+	assert.NoError(t, cache.SetAvailability(api.CacheAvailabilityDegraded))
+	availability, err := cache.Availability()
+	assert.NoError(t, err)
+	assert.Equal(t, api.CacheAvailabilityDegraded, availability)
+
+	assert.NoError(t, cache.Delete())
+	exists, err = cache.Exists()
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestBackupsAndRestores(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.Update(func(s *State) {
+		s.OperationStatus = api.StatusRunning
+	})
+
+	container := server.Infinispan().Container()
+	assert.NoError(t, container.Backups().Create("backup", &api.BackupConfig{Directory: "/opt/backups"}))
+	status, err := container.Backups().Status("backup")
+	assert.NoError(t, err)
+	assert.Equal(t, api.StatusRunning, status)
+
+	server.Update(func(s *State) {
+		s.Backups["backup"].Status = api.StatusSucceeded
+	})
+	status, err = container.Backups().Status("backup")
+	assert.NoError(t, err)
+	assert.Equal(t, api.StatusSucceeded, status)
+
+	assert.NoError(t, container.Restores().Create("restore", &api.RestoreConfig{Location: "/opt/backups/backup.zip"}))
+	server.Update(func(s *State) {
+		s.Restores["restore"].Status = api.StatusFailed
+	})
+	status, err = container.Restores().Status("restore")
+	assert.Error(t, err)
+	assert.Equal(t, api.StatusFailed, status)
+
+	_, err = container.Restores().Status("unknown")
+	assert.Error(t, err)
+
+	// Cancelling removes the operation, cancelling an unknown operation is not an error
+	assert.NoError(t, container.Backups().Cancel("backup"))
+	_, err = container.Backups().Status("backup")
+	assert.Error(t, err)
+	assert.NoError(t, container.Restores().Cancel("unknown"))
+}
+
+func TestLoggers(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	logging := server.Infinispan().Logging()
+	assert.NoError(t, logging.SetLogger("org.infinispan", "debug"))
+	loggers, err := logging.GetLoggers()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"org.infinispan": "DEBUG"}, loggers)
+}
+
+func TestRollingUpgrade(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.AddCache("test", "{}")
+
+	upgrade := server.Infinispan().Cache("test").RollingUpgrade()
+	connected, err := upgrade.SourceConnected()
+	assert.NoError(t, err)
+	assert.False(t, connected)
+
+	assert.NoError(t, upgrade.AddSource(`{"remote-store":{}}`, mime.ApplicationJson))
+	connected, err = upgrade.SourceConnected()
+	assert.NoError(t, err)
+	assert.True(t, connected)
+
+	_, err = upgrade.SyncData()
+	assert.NoError(t, err)
+	assert.NoError(t, upgrade.DisconnectSource())
+	connected, err = upgrade.SourceConnected()
+	assert.NoError(t, err)
+	assert.False(t, connected)
+}
+
+func TestXsite(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.Update(func(s *State) {
+		s.Sites = []string{"NYC"}
+	})
+	server.AddCache("a", "{}")
+	server.AddCache("b", "{}")
+
+	ispn := server.Infinispan()
+	xsite := ispn.Container().Xsite()
+	assert.NoError(t, ispn.Cache("a").Xsite().TakeOffline("NYC"))
+	statuses, err := xsite.Status()
+	assert.NoError(t, err)
+	assert.Equal(t, api.SiteStatus{Status: api.SiteStateMixed, Online: []string{"b"}, Offline: []string{"a"}}, statuses["NYC"])
+
+	assert.NoError(t, xsite.BringOnline("NYC"))
+	assert.NoError(t, xsite.PushState("NYC"))
+	pushStatus, err := ispn.Cache("a").Xsite().PushStateStatus()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]api.PushStateStatus{"NYC": api.PushStateStatusOK}, pushStatus)
+
+	cacheXsite := ispn.Cache("b").Xsite()
+	assert.NoError(t, cacheXsite.UpdateTakeOfflineConfig("NYC", &api.TakeOfflineConfig{AfterFailures: 3, MinWait: 1000}))
+	config, err := cacheXsite.TakeOfflineConfig("NYC")
+	assert.NoError(t, err)
+	assert.Equal(t, &api.TakeOfflineConfig{AfterFailures: 3, MinWait: 1000}, config)
+}
+
+func TestCounters(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	counters := server.Infinispan().Counters()
+	config, err := counters.Config("strong")
+	assert.NoError(t, err)
+	assert.Nil(t, config)
+
+	upper := int64(10)
+	assert.NoError(t, counters.Create("strong", &api.CounterConfig{Type: api.CounterTypeStrong, InitialValue: 1, UpperBound: &upper}))
+	assert.NoError(t, counters.Create("weak", &api.CounterConfig{Type: api.CounterTypeWeak, Storage: api.CounterStoragePersistent}))
+	config, err = counters.Config("strong")
+	assert.NoError(t, err)
+	assert.Equal(t, &api.CounterConfig{Type: api.CounterTypeStrong, InitialValue: 1, Storage: api.CounterStorageVolatile, UpperBound: &upper}, config)
+
+	value, err := counters.AddAndGet("strong", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), value)
+	value, err = counters.IncrementAndGet("weak")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), value)
+	set, err := counters.CompareAndSet("strong", 3, 5)
+	assert.NoError(t, err)
+	assert.True(t, set)
+	assert.NoError(t, counters.Reset("strong"))
+	value, err = counters.Get("strong")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), value)
+
+	assert.NoError(t, counters.Delete("weak"))
+	names, err := counters.Names()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"strong"}, names)
+}
+
+func TestSchemas(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	schemas := server.Infinispan().Schemas()
+	_, exists, err := schemas.Get("person.proto")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	assert.NoError(t, schemas.Register("person.proto", "message Person {}"))
+	assert.NoError(t, schemas.Update("person.proto", "message Person { optional string name = 1; }"))
+	content, exists, err := schemas.Get("person.proto")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, "message Person { optional string name = 1; }", content)
+
+	server.Update(func(s *State) {
+		s.Schemas["person.proto"].Error = &api.SchemaError{Message: "invalid", Cause: "syntax"}
+	})
+	schemaError, err := schemas.Errors("person.proto")
+	assert.NoError(t, err)
+	assert.Equal(t, &api.SchemaError{Message: "invalid", Cause: "syntax"}, schemaError)
+
+	assert.NoError(t, schemas.Delete("person.proto"))
+	infos, err := schemas.List()
+	assert.NoError(t, err)
+	assert.Empty(t, infos)
+}
+
+func TestTasks(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	tasks := server.Infinispan().Tasks()
+	_, err := tasks.Exec("hello.js", nil)
+	assert.Error(t, err)
+
+	assert.NoError(t, tasks.Create("hello.js", "'Hello ' + name"))
+	server.Update(func(s *State) {
+		s.Tasks["hello.js"].Result = "Hello World"
+	})
+	list, err := tasks.List(api.TaskTypeUser)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, "hello.js", list[0].Name)
+
+	result, err := tasks.Exec("hello.js", map[string]string{"name": "World"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello World", result)
+	server.Update(func(s *State) {
+		assert.Equal(t, []map[string]string{{"name": "World"}}, s.Tasks["hello.js"].Executions)
+	})
+}
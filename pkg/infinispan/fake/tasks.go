@@ -0,0 +1,59 @@
+package fake
+
+import (
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+)
+
+func (s *Server) tasks(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 || path[0] == "" {
+		if r.Method != http.MethodGet {
+			notImplemented(w, r)
+			return
+		}
+		// All tasks are user tasks, so the type filter is ignored
+		tasks := make([]api.Task, 0, len(s.state.Tasks))
+		for name := range s.state.Tasks {
+			tasks = append(tasks, api.Task{Name: name, Type: "Script"})
+		}
+		sort.Slice(tasks, func(i, j int) bool { return tasks[i].Name < tasks[j].Name })
+		writeJSON(w, tasks)
+		return
+	}
+	if len(path) > 1 || r.Method != http.MethodPost {
+		notImplemented(w, r)
+		return
+	}
+
+	name := path[0]
+	switch action(r) {
+	case "":
+		script, _ := ioutil.ReadAll(r.Body)
+		if task, exists := s.state.Tasks[name]; exists {
+			task.Script = string(script)
+		} else {
+			s.state.Tasks[name] = &Task{Script: string(script)}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case "exec":
+		task, exists := s.state.Tasks[name]
+		if !exists {
+			notFound(w, "task '%s' not found", name)
+			return
+		}
+		params := map[string]string{}
+		for key, values := range r.URL.Query() {
+			if strings.HasPrefix(key, "param.") {
+				params[strings.TrimPrefix(key, "param.")] = values[0]
+			}
+		}
+		task.Executions = append(task.Executions, params)
+		writeText(w, task.Result)
+	default:
+		notImplemented(w, r)
+	}
+}
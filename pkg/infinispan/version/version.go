@@ -1,21 +1,41 @@
 package version
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
 
 type Version struct {
-	Major uint8
-	Minor uint8
-	Patch uint8
+	Major uint64
+	Minor uint64
+	Patch uint64
 }
 
 type unknownError struct {
 	version *Version
 }
 
+var versionRegex = regexp.MustCompile(`(\d+)\.(\d+)(?:\.(\d+))?`)
+
 func (v *Version) String() string {
 	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
 }
 
+// Compare returns an integer comparing two Versions. The result will be 0 if v == o, -1 if v < o, and +1 if v > o.
+func (v *Version) Compare(o *Version) int {
+	for _, pair := range [][2]uint64{{v.Major, o.Major}, {v.Minor, o.Minor}, {v.Patch, o.Patch}} {
+		if pair[0] < pair[1] {
+			return -1
+		}
+		if pair[0] > pair[1] {
+			return 1
+		}
+	}
+	return 0
+}
+
 func (e *unknownError) Error() string {
 	return fmt.Sprintf("unknown version: %v", e.version)
 }
@@ -23,3 +43,35 @@ func (e *unknownError) Error() string {
 func UnknownError(v *Version) error {
 	return &unknownError{v}
 }
+
+// FromString parses the first <major>.<minor>[.<patch>] sequence in the provided string, allowing versions to be
+// extracted from the server's version string, e.g. "Infinispan 'Triskaidekaphobia' 13.0.10.Final"
+func FromString(s string) (*Version, error) {
+	match := versionRegex.FindStringSubmatch(s)
+	if match == nil {
+		return nil, fmt.Errorf("unable to parse version from '%s'", s)
+	}
+
+	parts := make([]uint64, 3)
+	for i, str := range match[1:] {
+		if str == "" {
+			continue
+		}
+		part, err := strconv.ParseUint(str, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse version from '%s': %w", s, err)
+		}
+		parts[i] = part
+	}
+	return &Version{Major: parts[0], Minor: parts[1], Patch: parts[2]}, nil
+}
+
+// FromImage parses the version from the tag of the provided container image, e.g. "quay.io/infinispan/server:13.0"
+func FromImage(image string) (*Version, error) {
+	name := strings.SplitN(image, "@", 2)[0]
+	i := strings.LastIndex(name, ":")
+	if i < 0 || strings.Contains(name[i:], "/") {
+		return nil, fmt.Errorf("unable to parse version from image '%s', no tag defined", image)
+	}
+	return FromString(name[i+1:])
+}
@@ -0,0 +1,32 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromString(t *testing.T) {
+	for s, expected := range map[string]string{
+		"13.0":           "13.0.0",
+		"14.0.1.Final":   "14.0.1",
+		"14.0.256.Final": "14.0.256",
+		"Infinispan 'Triskaidekaphobia' 13.0.10.Final": "13.0.10",
+	} {
+		v, err := FromString(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, expected, v.String())
+	}
+
+	_, err := FromString("latest")
+	assert.Error(t, err)
+	_, err = FromString("14.0.99999999999999999999")
+	assert.Error(t, err)
+}
+
+func TestCompare(t *testing.T) {
+	v := &Version{Major: 14, Minor: 0, Patch: 256}
+	assert.Equal(t, 0, v.Compare(&Version{Major: 14, Minor: 0, Patch: 256}))
+	assert.Equal(t, 1, v.Compare(&Version{Major: 14, Minor: 0, Patch: 255}))
+	assert.Equal(t, -1, v.Compare(&Version{Major: 14, Minor: 1}))
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"sort"
@@ -107,6 +108,10 @@ type ExecOptions struct {
 	Command   []string
 	Namespace string
 	PodName   string
+	// Stdin if not nil, is streamed to the stdin of the command
+	Stdin io.Reader
+	// Stdout if not nil, receives the stdout of the command instead of the returned buffer
+	Stdout io.Writer
 }
 
 type execError struct {
@@ -130,12 +135,16 @@ func (k Kubernetes) ExecWithOptions(options ExecOptions) (bytes.Buffer, error) {
 		VersionedParams(&corev1.PodExecOptions{
 			Container: options.Container,
 			Command:   options.Command,
-			Stdin:     false,
+			Stdin:     options.Stdin != nil,
 			Stdout:    true,
 			Stderr:    true,
 			TTY:       false,
 		}, scheme.ParameterCodec)
 	var execOut, execErr bytes.Buffer
+	var stdout io.Writer = &execOut
+	if options.Stdout != nil {
+		stdout = options.Stdout
+	}
 	// Create an executor
 	exec, err := remotecommand.NewSPDYExecutor(k.RestConfig, "POST", execRequest.URL())
 	if err != nil {
@@ -143,8 +152,8 @@ func (k Kubernetes) ExecWithOptions(options ExecOptions) (bytes.Buffer, error) {
 	}
 	// Run the command
 	err = exec.Stream(remotecommand.StreamOptions{
-		Stdin:  nil,
-		Stdout: &execOut,
+		Stdin:  options.Stdin,
+		Stdout: stdout,
 		Stderr: &execErr,
 		Tty:    false,
 	})
@@ -0,0 +1,426 @@
+// Package s3 provides a minimal client for storing and retrieving objects from S3 compatible object storage
+package s3
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultRegion  = "us-east-1"
+	DefaultTimeout = 30 * time.Second
+	// The size of the parts that objects larger than a single part are uploaded in
+	DefaultPartSize = 64 * 1024 * 1024
+	// The maximum size of an object supported by S3
+	MaxObjectSize = 5 * 1024 * 1024 * 1024 * 1024
+
+	maxParts = 10000
+
+	amzDateFormat   = "20060102T150405Z"
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+type Config struct {
+	// The URL of the S3 endpoint, e.g. https://s3.eu-west-1.amazonaws.com or http://minio:9000
+	Endpoint        string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// PEM encoded certificates used to verify the endpoint's certificate, e.g. for endpoints with a self-signed
+	// certificate. The system's certificate pool is used if undefined
+	CACert []byte
+	// The maximum time to establish a connection and to wait for the response once a request has been sent. The
+	// transfer of an object's content is not limited, so that large archives can be streamed. Defaults to DefaultTimeout
+	Timeout time.Duration
+}
+
+// Client executes requests against a S3 compatible endpoint using path-style addressing and AWS Signature Version 4
+// authentication. Object payloads are not signed so that they can be streamed without being buffered.
+type Client struct {
+	endpoint *url.URL
+	config   Config
+	client   *http.Client
+	now      func() time.Time
+	partSize int64
+}
+
+// Error represents an unexpected response returned by the endpoint
+type Error struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("unexpected response %d: %s", e.StatusCode, e.Body)
+}
+
+// IsNotFound returns true if the error was caused by a missing object or bucket
+func IsNotFound(err error) bool {
+	if e, ok := err.(*Error); ok {
+		return e.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
+// IsTransient returns true if the error was caused by a condition that may resolve itself, i.e. the request could not
+// be executed, the endpoint is unavailable or the request was throttled
+func IsTransient(err error) bool {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.StatusCode >= http.StatusInternalServerError || e.StatusCode == http.StatusTooManyRequests
+	}
+	return err != nil
+}
+
+func New(c Config) (*Client, error) {
+	endpoint, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint '%s': %w", c.Endpoint, err)
+	}
+	if endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid endpoint '%s': scheme and host must be defined", c.Endpoint)
+	}
+	if c.Region == "" {
+		c.Region = DefaultRegion
+	}
+	if c.Timeout == 0 {
+		c.Timeout = DefaultTimeout
+	}
+
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   c.Timeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSHandshakeTimeout:   c.Timeout,
+		ResponseHeaderTimeout: c.Timeout,
+		ExpectContinueTimeout: 1 * time.Second,
+		IdleConnTimeout:       90 * time.Second,
+	}
+	if c.CACert != nil {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(c.CACert) {
+			return nil, fmt.Errorf("invalid CA certificate: no PEM encoded certificates found")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	return &Client{
+		endpoint: endpoint,
+		config:   c,
+		client:   &http.Client{Transport: transport},
+		now:      time.Now,
+		partSize: DefaultPartSize,
+	}, nil
+}
+
+// PutObject uploads size bytes read from body to the object with the given key. Objects larger than a single part are
+// uploaded using a multipart upload, which is aborted if any of the parts cannot be uploaded.
+func (c *Client) PutObject(bucket, key string, body io.Reader, size int64) error {
+	if size > MaxObjectSize {
+		return fmt.Errorf("object size %d exceeds the maximum object size of %d bytes", size, int64(MaxObjectSize))
+	}
+	partSize := c.partSize
+	if minPartSize := (size + maxParts - 1) / maxParts; partSize < minPartSize {
+		partSize = minPartSize
+	}
+	if size <= partSize {
+		return c.putObject(bucket, key, body, size)
+	}
+
+	uploadId, err := c.createMultipartUpload(bucket, key)
+	if err != nil {
+		return err
+	}
+	if err := c.uploadParts(bucket, key, uploadId, body, size, partSize); err != nil {
+		// The parts already uploaded are only removed once the upload is aborted
+		if abortErr := c.abortMultipartUpload(bucket, key, uploadId); abortErr != nil {
+			return fmt.Errorf("%w, unable to abort multipart upload '%s': %v", err, uploadId, abortErr)
+		}
+		return err
+	}
+	return nil
+}
+
+type initiateMultipartUploadResult struct {
+	UploadId string `xml:"UploadId"`
+}
+
+type completeMultipartUpload struct {
+	XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+	Parts   []completedPart `xml:"Part"`
+}
+
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+func (c *Client) createMultipartUpload(bucket, key string) (string, error) {
+	req, err := c.newRequest(http.MethodPost, bucket, key, url.Values{"uploads": {""}}, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	rsp, err := c.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer rsp.Body.Close()
+	result := &initiateMultipartUploadResult{}
+	if err := xml.NewDecoder(rsp.Body).Decode(result); err != nil {
+		return "", fmt.Errorf("unable to decode multipart upload response: %w", err)
+	}
+	if result.UploadId == "" {
+		return "", fmt.Errorf("multipart upload response does not contain an UploadId")
+	}
+	return result.UploadId, nil
+}
+
+func (c *Client) uploadParts(bucket, key, uploadId string, body io.Reader, size, partSize int64) error {
+	complete := &completeMultipartUpload{}
+	for offset, partNumber := int64(0), 1; offset < size; offset, partNumber = offset+partSize, partNumber+1 {
+		length := partSize
+		if remaining := size - offset; remaining < length {
+			length = remaining
+		}
+		query := url.Values{"partNumber": {fmt.Sprint(partNumber)}, "uploadId": {uploadId}}
+		req, err := c.newRequest(http.MethodPut, bucket, key, query, io.LimitReader(body, length))
+		if err != nil {
+			return err
+		}
+		req.ContentLength = length
+		rsp, err := c.do(req)
+		if err != nil {
+			return fmt.Errorf("unable to upload part %d: %w", partNumber, err)
+		}
+		etag := rsp.Header.Get("ETag")
+		if err := rsp.Body.Close(); err != nil {
+			return err
+		}
+		complete.Parts = append(complete.Parts, completedPart{PartNumber: partNumber, ETag: etag})
+	}
+
+	payload, err := xml.Marshal(complete)
+	if err != nil {
+		return err
+	}
+	req, err := c.newRequest(http.MethodPost, bucket, key, url.Values{"uploadId": {uploadId}}, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	rsp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("unable to complete multipart upload: %w", err)
+	}
+	defer rsp.Body.Close()
+	// The endpoint may report an error after the response status has been sent
+	rspBody, err := ioutil.ReadAll(rsp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to complete multipart upload: %w", err)
+	}
+	if bytes.Contains(rspBody, []byte("<Error>")) {
+		return fmt.Errorf("unable to complete multipart upload: %w", &Error{StatusCode: http.StatusInternalServerError, Body: string(rspBody)})
+	}
+	return nil
+}
+
+func (c *Client) abortMultipartUpload(bucket, key, uploadId string) error {
+	req, err := c.newRequest(http.MethodDelete, bucket, key, url.Values{"uploadId": {uploadId}}, nil)
+	if err != nil {
+		return err
+	}
+	rsp, err := c.do(req)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	return rsp.Body.Close()
+}
+
+// putObject uploads size bytes read from body in a single request
+func (c *Client) putObject(bucket, key string, body io.Reader, size int64) error {
+	req, err := c.newRequest(http.MethodPut, bucket, key, nil, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/octet-stream")
+	rsp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	return rsp.Body.Close()
+}
+
+// GetObject returns a stream of the object's content and its size. The caller must close the returned ReadCloser.
+func (c *Client) GetObject(bucket, key string) (io.ReadCloser, int64, error) {
+	req, err := c.newRequest(http.MethodGet, bucket, key, nil, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	rsp, err := c.do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	return rsp.Body, rsp.ContentLength, nil
+}
+
+// HeadObject returns the size of the object, or an error satisfying IsNotFound if the object does not exist
+func (c *Client) HeadObject(bucket, key string) (int64, error) {
+	req, err := c.newRequest(http.MethodHead, bucket, key, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	rsp, err := c.do(req)
+	if err != nil {
+		return 0, err
+	}
+	return rsp.ContentLength, rsp.Body.Close()
+}
+
+// DeleteObject removes the object, no error is returned if the object does not exist
+func (c *Client) DeleteObject(bucket, key string) error {
+	req, err := c.newRequest(http.MethodDelete, bucket, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	rsp, err := c.do(req)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	return rsp.Body.Close()
+}
+
+func (c *Client) newRequest(method, bucket, key string, query url.Values, body io.Reader) (*http.Request, error) {
+	u := *c.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + bucket + "/" + strings.TrimPrefix(key, "/")
+	u.RawQuery = query.Encode()
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s request: %w", method, err)
+	}
+	return req, nil
+}
+
+// do signs and executes the request, returning an *Error if a non 2xx response is received
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	c.sign(req, c.now())
+	rsp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute %s request: %w", req.Method, err)
+	}
+	if rsp.StatusCode < 200 || rsp.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(rsp.Body)
+		_ = rsp.Body.Close()
+		return nil, &Error{StatusCode: rsp.StatusCode, Body: string(body)}
+	}
+	return rsp, nil
+}
+
+// sign adds the Authorization header to the request as defined by AWS Signature Version 4. All headers present on
+// the request are included in the signature.
+func (c *Client) sign(req *http.Request, t time.Time) {
+	t = t.UTC()
+	amzDate := t.Format(amzDateFormat)
+	date := amzDate[:8]
+	if req.Header.Get("X-Amz-Content-Sha256") == "" {
+		req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	}
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		req.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+
+	scope := strings.Join([]string{date, c.config.Region, "s3", "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hexSha256([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSha256([]byte("AWS4"+c.config.SecretAccessKey), date)
+	key = hmacSha256(key, c.config.Region)
+	key = hmacSha256(key, "s3")
+	key = hmacSha256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSha256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.config.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func canonicalQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var params []string
+	for _, k := range keys {
+		vs := values[k]
+		sort.Strings(vs)
+		for _, v := range vs {
+			params = append(params, uriEncode(k)+"="+uriEncode(v))
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+func uriEncode(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func hexSha256(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -12,7 +12,6 @@ import (
 	v1 "github.com/infinispan/infinispan-operator/api/v1"
 	v2 "github.com/infinispan/infinispan-operator/api/v2alpha1"
 	batchCtrl "github.com/infinispan/infinispan-operator/controllers"
-	ispnClient "github.com/infinispan/infinispan-operator/pkg/infinispan/client"
 	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
 	tutils "github.com/infinispan/infinispan-operator/test/e2e/utils"
 	batchv1 "k8s.io/api/batch/v1"
@@ -48,7 +47,7 @@ func testBatchInlineConfig(t *testing.T, infinispan *v1.Infinispan) {
 	helper.WaitForValidBatchPhase(name, v2.BatchSucceeded)
 
 	httpClient := tutils.HTTPClientForCluster(infinispan, testKube)
-	ispn := ispnClient.New(httpClient)
+	ispn := tutils.NewInfinispanClient(httpClient)
 	assertCacheExists("batch-cache", ispn)
 	assertCounterExists("batch-counter", ispn)
 	testKube.DeleteBatch(batch)
@@ -82,7 +81,7 @@ func TestBatchConfigMap(t *testing.T) {
 	waitForK8sResourceCleanup(infinispan.Name)
 
 	httpClient := tutils.HTTPClientForCluster(infinispan, testKube)
-	ispn := ispnClient.New(httpClient)
+	ispn := tutils.NewInfinispanClient(httpClient)
 	assertCacheExists("batch-cache", ispn)
 	assertCounterExists("batch-counter", ispn)
 }
@@ -11,7 +11,6 @@ import (
 	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
 	cconsts "github.com/infinispan/infinispan-operator/controllers/constants"
 	httpClient "github.com/infinispan/infinispan-operator/pkg/http"
-	ispnClient "github.com/infinispan/infinispan-operator/pkg/infinispan/client"
 	users "github.com/infinispan/infinispan-operator/pkg/infinispan/security"
 	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
 	"github.com/infinispan/infinispan-operator/pkg/mime"
@@ -129,7 +128,7 @@ func testAuthentication(ispn *ispnv1.Infinispan, schema, usr, pass string) {
 }
 
 func createCacheBadCreds(cacheName string, client tutils.HTTPClient) {
-	err := ispnClient.New(client).Cache(cacheName).Create("", mime.ApplicationYaml)
+	err := tutils.NewInfinispanClient(client).Cache(cacheName).Create("", mime.ApplicationYaml)
 	if err == nil {
 		panic("Cache creation should fail")
 	}
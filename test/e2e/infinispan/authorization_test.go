@@ -7,7 +7,6 @@ import (
 	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
 	v1 "github.com/infinispan/infinispan-operator/api/v1"
 	cconsts "github.com/infinispan/infinispan-operator/controllers/constants"
-	ispnClient "github.com/infinispan/infinispan-operator/pkg/infinispan/client"
 	users "github.com/infinispan/infinispan-operator/pkg/infinispan/security"
 	tutils "github.com/infinispan/infinispan-operator/test/e2e/utils"
 	"gopkg.in/yaml.v2"
@@ -31,7 +30,7 @@ func TestAuthorizationDisabledByDefault(t *testing.T) {
 	}
 
 	verify := func(client tutils.HTTPClient) {
-		_, err := ispnClient.New(client).Caches().Names()
+		_, err := tutils.NewInfinispanClient(client).Caches().Names()
 		tutils.ExpectNoError(err)
 	}
 	testAuthorization(ispn, identities, verify)
@@ -8,7 +8,6 @@ import (
 	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
 	"github.com/infinispan/infinispan-operator/controllers"
 	cconsts "github.com/infinispan/infinispan-operator/controllers/constants"
-	ispnClient "github.com/infinispan/infinispan-operator/pkg/infinispan/client"
 	tutils "github.com/infinispan/infinispan-operator/test/e2e/utils"
 	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -45,7 +44,7 @@ func TestTLSWithExistingKeystore(t *testing.T) {
 }
 
 func checkRestConnection(client tutils.HTTPClient) {
-	_, err := ispnClient.New(client).Container().Members()
+	_, err := tutils.NewInfinispanClient(client).Container().Members()
 	tutils.ExpectNoError(err)
 }
 
@@ -16,9 +16,14 @@ type CacheHelper struct {
 	CacheClient api.Cache
 }
 
+// NewInfinispanClient returns the api.Infinispan implementation matching the version reported by the server
+func NewInfinispanClient(client HTTPClient) api.Infinispan {
+	return ispnClient.New(client, log)
+}
+
 func NewCacheHelper(cacheName string, client HTTPClient) *CacheHelper {
 	return &CacheHelper{
-		CacheClient: ispnClient.New(client).Cache(cacheName),
+		CacheClient: NewInfinispanClient(client).Cache(cacheName),
 		CacheName:   cacheName,
 		Client:      client,
 	}
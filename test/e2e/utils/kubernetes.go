@@ -17,7 +17,6 @@ import (
 	"github.com/infinispan/infinispan-operator/controllers"
 	consts "github.com/infinispan/infinispan-operator/controllers/constants"
 	"github.com/infinispan/infinispan-operator/launcher/operator"
-	ispnClient "github.com/infinispan/infinispan-operator/pkg/infinispan/client"
 	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
 	routev1 "github.com/openshift/api/route/v1"
 	"gopkg.in/yaml.v2"
@@ -516,7 +515,7 @@ func getNodePort(service *corev1.Service) int32 {
 }
 
 func CheckExternalAddress(client HTTPClient) bool {
-	status, err := ispnClient.New(client).Container().HealthStatus()
+	status, err := NewInfinispanClient(client).Container().HealthStatus()
 	if isTemporary(err) {
 		return false
 	}